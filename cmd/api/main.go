@@ -1,13 +1,35 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/muktiarafi/ticketing-orders/internal/config"
 	"github.com/muktiarafi/ticketing-orders/internal/server"
 )
 
 func main() {
-	e := server.SetupServer()
+	s := server.SetupServer()
 
-	log.Fatal(e.Start(":8080"))
+	go func() {
+		if err := s.Start(":8080"); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	log.Println("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownGracePeriod())
+	defer cancel()
+
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,128 @@
+// Command dlq lists the dead letters of a topic or replays them to it.
+//
+//	dlq inspect -topic ticket-created [-uuid id1,id2]
+//	dlq replay -topic ticket-created [-uuid id1,id2]
+//
+// Replayed messages keep their id, so a message replayed twice is still
+// applied once by the order consumer.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/config"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+)
+
+type deadLetter struct {
+	UUID     string            `json:"uuid"`
+	Metadata map[string]string `json:"metadata"`
+	Payload  []byte            `json:"payload"`
+}
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] != "inspect" && os.Args[1] != "replay") {
+		fmt.Fprintln(os.Stderr, "usage: dlq inspect|replay -topic <topic> [-uuid <ids>] [-idle <duration>]")
+		os.Exit(2)
+	}
+	command := os.Args[1]
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	topic := flags.String("topic", "", "original topic of the dead letters, e.g. ticket-created")
+	uuids := flags.String("uuid", "", "comma separated ids of the messages to inspect or replay, all when empty")
+	idle := flags.Duration("idle", 5*time.Second, "stop once no message arrived for this long")
+	flags.Parse(os.Args[2:])
+	if *topic == "" {
+		log.Fatal("-topic is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// without a consumer group every run reads the dead letters from the start
+	subscriber, err := common.NewSubscriber(&common.SubscriberConfig{
+		Brokers:       []string{config.NewConsumerBroker()},
+		FromBeginning: true,
+		LoggerAdapter: watermill.NopLogger{},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer subscriber.Close()
+
+	messages, err := subscriber.Subscribe(ctx, schema.DeadLetterTopic(*topic))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var publisher message.Publisher
+	if command == "replay" {
+		publisher, err = common.NewPublisher([]string{config.NewProducerBroker()}, watermill.NopLogger{})
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer publisher.Close()
+	}
+
+	selected := make(map[string]bool)
+	for _, uuid := range strings.Split(*uuids, ",") {
+		if uuid = strings.TrimSpace(uuid); uuid != "" {
+			selected[uuid] = true
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	count := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(*idle):
+			log.Printf("%s %d dead letters of %s", command+"ed", count, *topic)
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			if len(selected) == 0 || selected[msg.UUID] {
+				switch command {
+				case "inspect":
+					err = encoder.Encode(&deadLetter{UUID: msg.UUID, Metadata: msg.Metadata, Payload: msg.Payload})
+				case "replay":
+					err = publisher.Publish(*topic, replay(msg))
+				}
+				if err != nil {
+					log.Fatal(err)
+				}
+				count++
+			}
+
+			msg.Ack()
+		}
+	}
+}
+
+// replay strips the dead-letter metadata off msg.
+func replay(msg *message.Message) *message.Message {
+	replayed := message.NewMessage(msg.UUID, msg.Payload)
+	for key, value := range msg.Metadata {
+		if !strings.HasPrefix(key, "dlq_") {
+			replayed.Metadata.Set(key, value)
+		}
+	}
+
+	return replayed
+}
@@ -0,0 +1,15 @@
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    uuid VARCHAR(64) NOT NULL UNIQUE,
+    topic VARCHAR(255) NOT NULL,
+    payload BYTEA NOT NULL,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    created_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    published_at TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at) WHERE published_at IS NULL;
+CREATE INDEX outbox_pending_topic_idx ON outbox (topic, id) WHERE published_at IS NULL;
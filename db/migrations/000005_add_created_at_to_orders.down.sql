@@ -0,0 +1,3 @@
+DROP INDEX orders_user_id_expires_at_idx;
+DROP INDEX orders_user_id_created_at_idx;
+ALTER TABLE orders DROP COLUMN created_at;
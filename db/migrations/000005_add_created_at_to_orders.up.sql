@@ -0,0 +1,4 @@
+ALTER TABLE orders ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC');
+
+CREATE INDEX orders_user_id_created_at_idx ON orders (user_id, created_at, id);
+CREATE INDEX orders_user_id_expires_at_idx ON orders (user_id, expires_at, id);
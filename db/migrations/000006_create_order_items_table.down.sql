@@ -0,0 +1,15 @@
+ALTER TABLE orders ADD COLUMN ticket_id INTEGER REFERENCES tickets (id) ON DELETE SET NULL;
+
+UPDATE orders AS o
+SET ticket_id = i.ticket_id
+FROM (
+    SELECT DISTINCT ON (order_id) order_id, ticket_id
+    FROM order_items
+    ORDER BY order_id, id
+) AS i
+WHERE o.id = i.order_id;
+
+ALTER TABLE orders ALTER COLUMN ticket_id SET NOT NULL;
+ALTER TABLE orders DROP COLUMN total;
+
+DROP TABLE order_items;
@@ -0,0 +1,28 @@
+CREATE TABLE order_items (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    ticket_id INTEGER NOT NULL REFERENCES tickets (id),
+    quantity INTEGER NOT NULL DEFAULT 1,
+    unit_price DECIMAL NOT NULL,
+    UNIQUE (order_id, ticket_id)
+);
+
+CREATE INDEX order_items_ticket_id_idx ON order_items (ticket_id);
+
+INSERT INTO order_items (order_id, ticket_id, quantity, unit_price)
+SELECT o.id, o.ticket_id, 1, t.price
+FROM orders AS o JOIN tickets AS t
+ON o.ticket_id = t.id;
+
+ALTER TABLE orders ADD COLUMN total DECIMAL NOT NULL DEFAULT 0;
+
+UPDATE orders AS o
+SET total = i.total
+FROM (
+    SELECT order_id, SUM(unit_price * quantity) AS total
+    FROM order_items
+    GROUP BY order_id
+) AS i
+WHERE o.id = i.order_id;
+
+ALTER TABLE orders DROP COLUMN ticket_id;
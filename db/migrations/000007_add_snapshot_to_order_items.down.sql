@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN currency;
+ALTER TABLE order_items DROP COLUMN currency;
+ALTER TABLE order_items DROP COLUMN title;
@@ -0,0 +1,10 @@
+ALTER TABLE order_items ADD COLUMN title VARCHAR(255);
+
+UPDATE order_items AS i
+SET title = t.title
+FROM tickets AS t
+WHERE i.ticket_id = t.id;
+
+ALTER TABLE order_items ALTER COLUMN title SET NOT NULL;
+ALTER TABLE order_items ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE orders ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
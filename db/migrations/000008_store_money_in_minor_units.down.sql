@@ -0,0 +1,8 @@
+ALTER TABLE orders ALTER COLUMN total DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN total TYPE DECIMAL USING total / 100.0;
+ALTER TABLE orders ALTER COLUMN total SET DEFAULT 0;
+
+ALTER TABLE order_items ALTER COLUMN unit_price TYPE DECIMAL USING unit_price / 100.0;
+
+ALTER TABLE tickets ALTER COLUMN price TYPE DECIMAL USING price / 100.0;
+ALTER TABLE tickets DROP COLUMN currency;
@@ -0,0 +1,10 @@
+-- Amounts are stored in the minor unit of their currency, e.g. cents. Every
+-- existing row is priced in USD, which has two decimals.
+ALTER TABLE tickets ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE tickets ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+
+ALTER TABLE order_items ALTER COLUMN unit_price TYPE BIGINT USING ROUND(unit_price * 100);
+
+ALTER TABLE orders ALTER COLUMN total DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN total TYPE BIGINT USING ROUND(total * 100);
+ALTER TABLE orders ALTER COLUMN total SET DEFAULT 0;
@@ -0,0 +1 @@
+DROP TABLE order_events;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE order_events (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    from_status VARCHAR(45),
+    to_status VARCHAR(45) NOT NULL,
+    actor_type VARCHAR(45) NOT NULL,
+    actor_id INTEGER,
+    reason TEXT NOT NULL DEFAULT '',
+    source_event_id VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC')
+);
+
+CREATE INDEX order_events_order_id_idx ON order_events (order_id, id);
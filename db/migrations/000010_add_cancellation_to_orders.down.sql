@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP COLUMN cancellation_note;
+ALTER TABLE orders DROP COLUMN cancellation_reason;
@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN cancellation_reason VARCHAR(45);
+ALTER TABLE orders ADD COLUMN cancellation_note TEXT;
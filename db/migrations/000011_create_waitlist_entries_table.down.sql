@@ -0,0 +1 @@
+DROP TABLE waitlist_entries;
\ No newline at end of file
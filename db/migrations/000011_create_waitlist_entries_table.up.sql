@@ -0,0 +1,12 @@
+CREATE TABLE waitlist_entries (
+    id SERIAL PRIMARY KEY,
+    ticket_id INTEGER NOT NULL REFERENCES tickets (id),
+    user_id INTEGER NOT NULL,
+    status VARCHAR(45) NOT NULL DEFAULT 'WAITING',
+    order_id INTEGER REFERENCES orders (id),
+    created_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    updated_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC')
+);
+
+CREATE UNIQUE INDEX waitlist_entries_waiting_idx ON waitlist_entries (ticket_id, user_id) WHERE status = 'WAITING';
+CREATE INDEX waitlist_entries_queue_idx ON waitlist_entries (ticket_id, id) WHERE status = 'WAITING';
@@ -0,0 +1 @@
+DROP TABLE admin_actions;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE admin_actions (
+    id SERIAL PRIMARY KEY,
+    admin_id INTEGER NOT NULL,
+    admin_email VARCHAR(255) NOT NULL,
+    action VARCHAR(45) NOT NULL,
+    order_id INTEGER REFERENCES orders (id) ON DELETE SET NULL,
+    ticket_id INTEGER REFERENCES tickets (id) ON DELETE SET NULL,
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC')
+);
+
+CREATE INDEX admin_actions_order_id_idx ON admin_actions (order_id, id);
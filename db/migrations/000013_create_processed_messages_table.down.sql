@@ -0,0 +1 @@
+DROP TABLE processed_messages;
\ No newline at end of file
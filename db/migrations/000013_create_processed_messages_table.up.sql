@@ -0,0 +1,5 @@
+CREATE TABLE processed_messages (
+    message_id VARCHAR(255) PRIMARY KEY,
+    topic VARCHAR(255) NOT NULL,
+    processed_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC')
+);
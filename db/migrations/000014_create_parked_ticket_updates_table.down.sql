@@ -0,0 +1 @@
+DROP TABLE parked_ticket_updates;
\ No newline at end of file
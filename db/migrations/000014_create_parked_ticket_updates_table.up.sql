@@ -0,0 +1,11 @@
+CREATE TABLE parked_ticket_updates (
+    ticket_id INTEGER NOT NULL,
+    version INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    price BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    parked_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (ticket_id, version)
+);
+
+CREATE INDEX parked_ticket_updates_parked_at_idx ON parked_ticket_updates (parked_at);
@@ -0,0 +1,12 @@
+CREATE TABLE order_payments (
+    id SERIAL PRIMARY KEY,
+    payment_id BIGINT NOT NULL UNIQUE,
+    order_id BIGINT NOT NULL,
+    stripe_id VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(45) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC')
+);
+
+CREATE INDEX order_payments_order_id_idx ON order_payments (order_id);
+CREATE INDEX order_payments_mismatched_idx ON order_payments (created_at) WHERE status = 'MISMATCHED';
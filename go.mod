@@ -3,9 +3,10 @@ module github.com/muktiarafi/ticketing-orders
 go 1.16
 
 require (
-	github.com/Shopify/sarama v1.29.0 // indirect
+	github.com/Shopify/sarama v1.29.0
 	github.com/ThreeDotsLabs/watermill v1.1.1
 	github.com/go-playground/validator/v10 v10.6.1
+	github.com/gogo/protobuf v1.3.2
 	github.com/golang-migrate/migrate/v4 v4.14.1
 	github.com/hashicorp/errwrap v1.1.0 // indirect
 	github.com/hashicorp/go-multierror v1.1.1 // indirect
@@ -0,0 +1,19 @@
+package clock
+
+import "time"
+
+// Clock lets services read the current time without calling time.Now
+// directly, so tests can pin it.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func New() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Admins lists the users allowed on the admin API. User tokens carry no
+// role, so admins are recognised by email or user id.
+type Admins struct {
+	Emails  map[string]bool
+	UserIDs map[int64]bool
+}
+
+// AdminUsers reads the comma separated ADMIN_EMAILS and ADMIN_USER_IDS.
+func AdminUsers() *Admins {
+	admins := &Admins{
+		Emails:  make(map[string]bool),
+		UserIDs: make(map[int64]bool),
+	}
+
+	for _, email := range splitList(os.Getenv("ADMIN_EMAILS")) {
+		admins.Emails[strings.ToLower(email)] = true
+	}
+	for _, value := range splitList(os.Getenv("ADMIN_USER_IDS")) {
+		userID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			log.Printf("ADMIN_USER_IDS: invalid user id %q", value)
+			continue
+		}
+		admins.UserIDs[userID] = true
+	}
+
+	return admins
+}
+
+// Has tells whether the user is an admin.
+func (a *Admins) Has(userID int64, email string) bool {
+	return a.UserIDs[userID] || a.Emails[strings.ToLower(email)]
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
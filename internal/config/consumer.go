@@ -0,0 +1,35 @@
+package config
+
+import "time"
+
+// Retry bounds how often a consumed message is handled before it is moved to
+// its dead-letter topic. The wait between attempts doubles from
+// InitialBackoff up to MaxBackoff.
+type Retry struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// ConsumerRetry reads CONSUMER_MAX_ATTEMPTS, CONSUMER_RETRY_BACKOFF and
+// CONSUMER_RETRY_MAX_BACKOFF.
+func ConsumerRetry() *Retry {
+	return &Retry{
+		MaxAttempts:    intFromEnv("CONSUMER_MAX_ATTEMPTS", 5),
+		InitialBackoff: durationFromEnv("CONSUMER_RETRY_BACKOFF", 100*time.Millisecond),
+		MaxBackoff:     durationFromEnv("CONSUMER_RETRY_MAX_BACKOFF", 10*time.Second),
+	}
+}
+
+// Backoff returns the wait after the given failed attempt, starting at 1.
+func (r *Retry) Backoff(attempt int) time.Duration {
+	backoff := r.InitialBackoff
+	for i := 1; i < attempt && backoff < r.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+
+	return backoff
+}
@@ -0,0 +1,17 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// Currency is the ISO 4217 code ticket prices are quoted in. Ticket events do
+// not carry a currency, so every order is priced in this one.
+func Currency() string {
+	currency := strings.ToUpper(os.Getenv("CURRENCY"))
+	if len(currency) != 3 {
+		return "USD"
+	}
+
+	return currency
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func intFromEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func boolFromEnv(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
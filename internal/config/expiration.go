@@ -0,0 +1,100 @@
+package config
+
+import (
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/money"
+)
+
+// Expiration decides how long a new order keeps its tickets reserved.
+// Ticket overrides win over price tiers, which win over Default.
+type Expiration struct {
+	Default    time.Duration
+	PriceTiers []PriceTier
+	Tickets    map[int64]time.Duration
+}
+
+// PriceTier applies to tickets priced at MinPrice or more, in the same
+// currency.
+type PriceTier struct {
+	MinPrice money.Money
+	Duration time.Duration
+}
+
+// OrderExpiration reads ORDER_EXPIRATION (e.g. "60s"),
+// ORDER_EXPIRATION_PRICE_TIERS (e.g. "100:2m,500:5m", priced in CURRENCY) and
+// ORDER_EXPIRATION_TICKETS (e.g. "12:10m", keyed by ticket id).
+func OrderExpiration() *Expiration {
+	expiration := &Expiration{
+		Default: durationFromEnv("ORDER_EXPIRATION", time.Minute),
+		Tickets: make(map[int64]time.Duration),
+	}
+
+	for key, duration := range parseDurationPairs("ORDER_EXPIRATION_PRICE_TIERS") {
+		minPrice, err := money.Parse(key, Currency())
+		if err != nil {
+			log.Printf("ORDER_EXPIRATION_PRICE_TIERS: invalid price %q", key)
+			continue
+		}
+		expiration.PriceTiers = append(expiration.PriceTiers, PriceTier{MinPrice: minPrice, Duration: duration})
+	}
+	sort.Slice(expiration.PriceTiers, func(i, j int) bool {
+		return expiration.PriceTiers[i].MinPrice.Amount > expiration.PriceTiers[j].MinPrice.Amount
+	})
+
+	for key, duration := range parseDurationPairs("ORDER_EXPIRATION_TICKETS") {
+		ticketID, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			log.Printf("ORDER_EXPIRATION_TICKETS: invalid ticket id %q", key)
+			continue
+		}
+		expiration.Tickets[ticketID] = duration
+	}
+
+	return expiration
+}
+
+// For returns the reservation window of a ticket.
+func (e *Expiration) For(ticketID int64, price money.Money) time.Duration {
+	if duration, ok := e.Tickets[ticketID]; ok {
+		return duration
+	}
+
+	for _, tier := range e.PriceTiers {
+		if price.Currency == tier.MinPrice.Currency && price.Amount >= tier.MinPrice.Amount {
+			return tier.Duration
+		}
+	}
+
+	return e.Default
+}
+
+func parseDurationPairs(key string) map[string]time.Duration {
+	pairs := make(map[string]time.Duration)
+	value := os.Getenv(key)
+	if value == "" {
+		return pairs
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 {
+			log.Printf("%s: invalid entry %q", key, pair)
+			continue
+		}
+
+		duration, err := time.ParseDuration(parts[1])
+		if err != nil {
+			log.Printf("%s: invalid duration %q", key, parts[1])
+			continue
+		}
+		pairs[parts[0]] = duration
+	}
+
+	return pairs
+}
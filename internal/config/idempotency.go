@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+func IdempotencyKeyWindow() time.Duration {
+	return durationFromEnv("IDEMPOTENCY_KEY_WINDOW", 24*time.Hour)
+}
+
+// IdempotencyKeyLease bounds how long a key stays reserved by a request that
+// never completed, e.g. because the process crashed while handling it.
+func IdempotencyKeyLease() time.Duration {
+	return durationFromEnv("IDEMPOTENCY_KEY_LEASE", time.Minute)
+}
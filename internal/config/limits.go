@@ -0,0 +1,37 @@
+package config
+
+import "time"
+
+// Limits caps what a single user may order. A zero value disables a rule.
+type Limits struct {
+	// MaxActiveOrders caps the orders waiting for payment.
+	MaxActiveOrders int
+	// MaxOrdersPerTitle caps the orders a user holds or has paid for tickets
+	// sharing a title. Tickets carry no event id, so the limit is per title;
+	// a renamed ticket counts under both its old and its new title.
+	MaxOrdersPerTitle int
+	Cooldown          Cooldown
+}
+
+// Cooldown blocks new orders for Duration once a user let Expirations orders
+// expire within Window.
+type Cooldown struct {
+	Expirations int
+	Window      time.Duration
+	Duration    time.Duration
+}
+
+// OrderLimits reads ORDER_LIMIT_ACTIVE, ORDER_LIMIT_PER_TITLE,
+// ORDER_COOLDOWN_EXPIRATIONS, ORDER_COOLDOWN_WINDOW and
+// ORDER_COOLDOWN_DURATION. Every rule is disabled by default.
+func OrderLimits() *Limits {
+	return &Limits{
+		MaxActiveOrders:   intFromEnv("ORDER_LIMIT_ACTIVE", 0),
+		MaxOrdersPerTitle: intFromEnv("ORDER_LIMIT_PER_TITLE", 0),
+		Cooldown: Cooldown{
+			Expirations: intFromEnv("ORDER_COOLDOWN_EXPIRATIONS", 0),
+			Window:      durationFromEnv("ORDER_COOLDOWN_WINDOW", 24*time.Hour),
+			Duration:    durationFromEnv("ORDER_COOLDOWN_DURATION", time.Hour),
+		},
+	}
+}
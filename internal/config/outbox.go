@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+func OutboxRelayInterval() time.Duration {
+	return durationFromEnv("OUTBOX_RELAY_INTERVAL", time.Second)
+}
+
+func OutboxBatchSize() int {
+	return intFromEnv("OUTBOX_BATCH_SIZE", 100)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 )
 
 func PostgresDSN() string {
@@ -21,3 +22,11 @@ func PostgresDSN() string {
 		password,
 	)
 }
+
+func PostgresReadTimeout() time.Duration {
+	return durationFromEnv("DB_READ_TIMEOUT", 3*time.Second)
+}
+
+func PostgresWriteTimeout() time.Duration {
+	return durationFromEnv("DB_WRITE_TIMEOUT", 3*time.Second)
+}
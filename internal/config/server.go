@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+func ShutdownGracePeriod() time.Duration {
+	return durationFromEnv("SHUTDOWN_GRACE_PERIOD", 30*time.Second)
+}
+
+func HealthCheckTimeout() time.Duration {
+	return durationFromEnv("HEALTH_CHECK_TIMEOUT", 2*time.Second)
+}
@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+func ExpirationSweeperEnabled() bool {
+	return boolFromEnv("EXPIRATION_SWEEPER_ENABLED", false)
+}
+
+func ExpirationSweeperInterval() time.Duration {
+	return durationFromEnv("EXPIRATION_SWEEPER_INTERVAL", 10*time.Second)
+}
+
+// ExpirationSweeperGrace gives the expiration service time to act before the
+// sweeper steps in.
+func ExpirationSweeperGrace() time.Duration {
+	return durationFromEnv("EXPIRATION_SWEEPER_GRACE", 30*time.Second)
+}
+
+func ExpirationSweeperBatchSize() int {
+	return intFromEnv("EXPIRATION_SWEEPER_BATCH_SIZE", 100)
+}
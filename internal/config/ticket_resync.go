@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// TicketUpdateMaxWait is how long an out-of-order ticket update waits for its
+// predecessor before the ticket is resynced.
+func TicketUpdateMaxWait() time.Duration {
+	return durationFromEnv("TICKET_UPDATE_MAX_WAIT", time.Minute)
+}
+
+func TicketResyncInterval() time.Duration {
+	return durationFromEnv("TICKET_RESYNC_INTERVAL", 10*time.Second)
+}
+
+func TicketResyncBatchSize() int {
+	return intFromEnv("TICKET_RESYNC_BATCH_SIZE", 100)
+}
@@ -0,0 +1,10 @@
+package constant
+
+// Actions recorded in the admin audit trail.
+const (
+	AdminSearchOrders  = "SEARCH_ORDERS"
+	AdminViewOrder     = "VIEW_ORDER"
+	AdminCancelOrder   = "CANCEL_ORDER"
+	AdminCompleteOrder = "COMPLETE_ORDER"
+	AdminViewTicket    = "VIEW_TICKET"
+)
@@ -0,0 +1,15 @@
+package constant
+
+// Reasons a user may give when cancelling an order.
+const (
+	ReasonChangedMind      = "CHANGED_MIND"
+	ReasonOrderedByMistake = "ORDERED_BY_MISTAKE"
+	ReasonFoundBetterPrice = "FOUND_BETTER_PRICE"
+	ReasonOther            = "OTHER"
+)
+
+// Reasons set by the system.
+const (
+	ReasonExpired          = "EXPIRED"
+	ReasonCancelledByAdmin = "CANCELLED_BY_ADMIN"
+)
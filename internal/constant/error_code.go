@@ -0,0 +1,14 @@
+package constant
+
+// Error codes on top of the ones in ticketing-common. handler.ErrorHandler
+// maps them to HTTP statuses.
+const (
+	EPRECONDITION  = "precondition_failed"
+	EACTIVELIMIT   = "active_order_limit"
+	EPURCHASELIMIT = "purchase_limit"
+	ECOOLDOWN      = "order_cooldown"
+	EFORBIDDEN     = "forbidden"
+	// EKEYREUSED is returned when an Idempotency-Key is sent again with a
+	// different request.
+	EKEYREUSED = "idempotency_key_reused"
+)
@@ -0,0 +1,10 @@
+package constant
+
+// Actors recorded in the order history.
+const (
+	ActorUser       = "USER"
+	ActorExpiration = "EXPIRATION"
+	ActorPayment    = "PAYMENT"
+	ActorAdmin      = "ADMIN"
+	ActorWaitlist   = "WAITLIST"
+)
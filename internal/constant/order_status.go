@@ -5,4 +5,8 @@ const (
 	CANCELLED = "CANCELLED"
 	PENDING   = "PENDING"
 	COMPLETED = "COMPLETED"
+	// REFUND_REQUESTED orders keep their tickets until the refund is
+	// confirmed and the order becomes REFUNDED.
+	REFUND_REQUESTED = "REFUND_REQUESTED"
+	REFUNDED         = "REFUNDED"
 )
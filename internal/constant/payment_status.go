@@ -0,0 +1,8 @@
+package constant
+
+// Outcomes of a payment received for an order. A payment is MISMATCHED when
+// its order could no longer be completed, and must be refunded.
+const (
+	PAYMENT_ACCEPTED   = "ACCEPTED"
+	PAYMENT_MISMATCHED = "MISMATCHED"
+)
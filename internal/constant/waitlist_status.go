@@ -0,0 +1,8 @@
+package constant
+
+const (
+	WAITLIST_WAITING  = "WAITING"
+	WAITLIST_PROMOTED = "PROMOTED"
+	WAITLIST_LEFT     = "LEFT"
+	WAITLIST_SKIPPED  = "SKIPPED"
+)
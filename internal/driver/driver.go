@@ -2,8 +2,6 @@ package driver
 
 import (
 	"database/sql"
-	"os"
-	"path/filepath"
 	"time"
 
 	_ "github.com/jackc/pgconn"
@@ -13,6 +11,14 @@ import (
 
 type DB struct {
 	SQL *sql.DB
+	Timeouts
+}
+
+// Timeouts bound how long a single read or write statement may run. A zero
+// value falls back to the repository default.
+type Timeouts struct {
+	Read  time.Duration
+	Write time.Duration
 }
 
 const (
@@ -21,7 +27,7 @@ const (
 	maxDBLifetime = 5 * time.Minute
 )
 
-func ConnectSQL(dsn string) (*DB, error) {
+func ConnectSQL(dsn string, timeouts Timeouts) (*DB, error) {
 	db, err := sql.Open("pgx", dsn)
 	if err != nil {
 		return nil, err
@@ -35,14 +41,13 @@ func ConnectSQL(dsn string) (*DB, error) {
 		return nil, err
 	}
 
-	pwd, err := os.Getwd()
+	migrationFilePath, err := MigrationFilePath()
 	if err != nil {
 		return nil, err
 	}
-	migrationFilePath := filepath.Join(pwd, "db", "migrations")
 	if err := Migration(migrationFilePath, db); err != nil {
 		return nil, err
 	}
 
-	return &DB{db}, nil
+	return &DB{SQL: db, Timeouts: timeouts}, nil
 }
@@ -1,15 +1,30 @@
 package driver
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
+func MigrationFilePath() (string, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(pwd, "db", "migrations"), nil
+}
+
 func Migration(migrationFilePath string, db *sql.DB) error {
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
 	if err != nil {
@@ -31,3 +46,40 @@ func Migration(migrationFilePath string, db *sql.DB) error {
 
 	return nil
 }
+
+// MigrationVersion reads the state golang-migrate keeps in schema_migrations.
+func MigrationVersion(ctx context.Context, db *sql.DB) (uint, bool, error) {
+	var version uint
+	var dirty bool
+	stmt := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	if err := db.QueryRowContext(ctx, stmt).Scan(&version, &dirty); err != nil {
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
+
+func LatestMigrationVersion(migrationFilePath string) (uint, error) {
+	files, err := ioutil.ReadDir(migrationFilePath)
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".up.sql") {
+			continue
+		}
+
+		prefix := strings.SplitN(file.Name(), "_", 2)[0]
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		if uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+
+	return latest, nil
+}
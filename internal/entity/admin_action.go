@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// AdminAction is one entry of the admin audit trail. OrderID and TicketID
+// are set when the action concerned an order or a ticket.
+type AdminAction struct {
+	ID         int64     `json:"id"`
+	AdminID    int64     `json:"adminId"`
+	AdminEmail string    `json:"adminEmail"`
+	Action     string    `json:"action"`
+	OrderID    int64     `json:"orderId,omitempty"`
+	TicketID   int64     `json:"ticketId,omitempty"`
+	Details    string    `json:"details"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
@@ -0,0 +1,7 @@
+package entity
+
+// Cancellation explains why an order was cancelled.
+type Cancellation struct {
+	ReasonCode string `json:"reasonCode"`
+	Note       string `json:"note,omitempty"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// IdempotencyKey holds the response of the first request sent with a key.
+// StatusCode is zero while that request is still being processed.
+type IdempotencyKey struct {
+	UserID       int64
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	CreatedAt    time.Time
+}
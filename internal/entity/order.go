@@ -1,12 +1,20 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/money"
+)
 
 type Order struct {
-	ID        int64     `json:"id"`
-	Status    string    `json:"status"`
-	ExpiresAt time.Time `json:"expiresAt"`
-	Version   int64     `json:"version"`
-	UserID    int64     `json:"userId"`
-	*Ticket   `json:"ticket"`
+	ID        int64        `json:"id"`
+	Status    string       `json:"status"`
+	ExpiresAt time.Time    `json:"expiresAt"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Version   int64        `json:"version"`
+	UserID    int64        `json:"userId"`
+	Total     money.Money  `json:"total"`
+	Items     []*OrderItem `json:"items"`
+	// Cancellation is set once the order is cancelled.
+	Cancellation *Cancellation `json:"cancellation,omitempty"`
 }
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// OrderEvent is one entry of an order's status history. FromStatus is empty
+// for the entry recording the order creation, ActorID is set for users and
+// admins, and SourceEventID is the id of the message that caused the change.
+type OrderEvent struct {
+	ID            int64     `json:"id"`
+	OrderID       int64     `json:"orderId"`
+	FromStatus    string    `json:"fromStatus,omitempty"`
+	ToStatus      string    `json:"toStatus"`
+	ActorType     string    `json:"actorType"`
+	ActorID       int64     `json:"actorId,omitempty"`
+	Reason        string    `json:"reason"`
+	SourceEventID string    `json:"sourceEventId,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/muktiarafi/ticketing-orders/internal/money"
+
+// OrderItem is one ticket of an order. Title and UnitPrice are copied from the
+// ticket when the order is placed, so later ticket updates do not change the
+// order. Ticket holds the current ticket.
+type OrderItem struct {
+	ID        int64       `json:"id"`
+	OrderID   int64       `json:"orderId"`
+	Title     string      `json:"title"`
+	Quantity  int         `json:"quantity"`
+	UnitPrice money.Money `json:"unitPrice"`
+	*Ticket   `json:"ticket"`
+}
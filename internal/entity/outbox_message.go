@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type OutboxMessage struct {
+	ID            int64
+	UUID          string
+	Topic         string
+	Payload       []byte
+	Metadata      map[string]string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
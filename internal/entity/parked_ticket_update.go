@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// ParkedTicketUpdate is a ticket update that arrived before the update
+// preceding it. It is applied once the gap is filled, or by a resync.
+type ParkedTicketUpdate struct {
+	*Ticket
+	ParkedAt time.Time `json:"parkedAt"`
+}
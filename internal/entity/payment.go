@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// Payment is a payment received for an order, kept for reconciliation. ID is
+// the id given by the payments service. OrderID may name an order this
+// service does not know.
+type Payment struct {
+	ID        int64     `json:"id"`
+	OrderID   int64     `json:"orderId"`
+	StripeID  string    `json:"stripeId"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -1,8 +1,10 @@
 package entity
 
+import "github.com/muktiarafi/ticketing-orders/internal/money"
+
 type Ticket struct {
-	ID      int64   `json:"id"`
-	Title   string  `json:"title"`
-	Price   float64 `json:"price"`
-	Version int64   `json:"version"`
+	ID      int64       `json:"id"`
+	Title   string      `json:"title"`
+	Price   money.Money `json:"price"`
+	Version int64       `json:"version"`
 }
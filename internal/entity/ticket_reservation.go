@@ -0,0 +1,8 @@
+package entity
+
+// TicketReservation shows who holds a ticket and how many users wait for it.
+type TicketReservation struct {
+	Ticket  *Ticket  `json:"ticket"`
+	Orders  []*Order `json:"orders"`
+	Waiting int      `json:"waiting"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// WaitlistEntry queues a user for a reserved ticket. OrderID is set once the
+// user has been promoted and an order was created for them. Position is only
+// filled while the entry is waiting, starting at 1.
+type WaitlistEntry struct {
+	ID        int64     `json:"id"`
+	TicketID  int64     `json:"ticketId"`
+	UserID    int64     `json:"userId"`
+	Status    string    `json:"status"`
+	OrderID   int64     `json:"orderId,omitempty"`
+	Position  int       `json:"position,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,160 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/config"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+)
+
+// Dispatcher routes topics to handlers like common.Consumer, but keeps track
+// of running handlers so it can be drained before the subscriber is closed.
+// A message its handler nacks is retried with backoff, and moved to the
+// dead-letter topic of its topic once retry.MaxAttempts is reached.
+type Dispatcher struct {
+	message.Subscriber
+	deadLetters message.Publisher
+	retry       *config.Retry
+	stop        chan struct{}
+	wg          sync.WaitGroup
+}
+
+func NewDispatcher(subscriber message.Subscriber, deadLetters message.Publisher, retry *config.Retry) *Dispatcher {
+	return &Dispatcher{
+		Subscriber:  subscriber,
+		deadLetters: deadLetters,
+		retry:       retry,
+		stop:        make(chan struct{}),
+	}
+}
+
+func (d *Dispatcher) On(topic string, eventHandler common.EventHandler) error {
+	messages, err := d.Subscribe(context.Background(), topic)
+	if err != nil {
+		return err
+	}
+
+	d.wg.Add(1)
+	go d.process(topic, handlerName(eventHandler), messages, eventHandler)
+
+	return nil
+}
+
+func (d *Dispatcher) process(topic, name string, messages <-chan *message.Message, eventHandler common.EventHandler) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			select {
+			case <-d.stop:
+				// left unacked, the broker redelivers it to the next consumer
+				return
+			default:
+			}
+
+			if err := d.handle(topic, name, msg, eventHandler); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// handle runs eventHandler on copies of msg until one is not nacked, then
+// acks msg. When every attempt was nacked msg is dead-lettered instead.
+func (d *Dispatcher) handle(topic, name string, msg *message.Message, eventHandler common.EventHandler) error {
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		try := msg.Copy()
+		try.SetContext(msg.Context())
+
+		err = eventHandler(try)
+		select {
+		case <-try.Nacked():
+		default:
+			msg.Ack()
+			return err
+		}
+
+		if attempt >= d.retry.MaxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(d.retry.Backoff(attempt))
+		select {
+		case <-d.stop:
+			timer.Stop()
+			// left unacked, the broker redelivers it to the next consumer
+			return err
+		case <-timer.C:
+		}
+	}
+
+	return d.deadLetter(topic, name, msg, attempt, err)
+}
+
+func (d *Dispatcher) deadLetter(topic, name string, msg *message.Message, attempts int, cause error) error {
+	deadLetter := msg.Copy()
+	deadLetter.Metadata.Set(schema.DeadLetterOriginalTopic, topic)
+	deadLetter.Metadata.Set(schema.DeadLetterHandler, name)
+	deadLetter.Metadata.Set(schema.DeadLetterAttempts, strconv.Itoa(attempts))
+	deadLetter.Metadata.Set(schema.DeadLetterFailedAt, time.Now().UTC().Format(time.RFC3339))
+	if cause != nil {
+		deadLetter.Metadata.Set(schema.DeadLetterError, cause.Error())
+	}
+
+	if err := d.deadLetters.Publish(schema.DeadLetterTopic(topic), deadLetter); err != nil {
+		msg.Nack()
+		return &common.Error{Op: "Dispatcher.deadLetter", Err: err}
+	}
+	msg.Ack()
+
+	return fmt.Errorf("message %s of %s moved to %s after %d attempts: %v", msg.UUID, topic, schema.DeadLetterTopic(topic), attempts, cause)
+}
+
+// handlerName names a handler for the dead-letter metadata, e.g.
+// "consumer.(*OrderConsumer).TicketCreated".
+func handlerName(eventHandler common.EventHandler) string {
+	name := runtime.FuncForPC(reflect.ValueOf(eventHandler).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// Close stops taking new messages, waits for running handlers until ctx is
+// done and then closes the subscriber, which cancels the context of any
+// handler still running.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	close(d.stop)
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Println("grace period is over, closing subscriber with handlers still running")
+	}
+
+	return d.Subscriber.Close()
+}
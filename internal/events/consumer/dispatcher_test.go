@@ -0,0 +1,68 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/muktiarafi/ticketing-orders/internal/config"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+)
+
+func TestDispatcherDeadLetters(t *testing.T) {
+	pubSub := gochannel.NewGoChannel(gochannel.Config{}, watermill.NopLogger{})
+	deadLetters, err := pubSub.Subscribe(context.Background(), schema.DeadLetterTopic("poison"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dispatcher := NewDispatcher(pubSub, pubSub, &config.Retry{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+	defer dispatcher.Close(context.Background())
+
+	var attempts int32
+	if err := dispatcher.On("poison", func(msg *message.Message) error {
+		atomic.AddInt32(&attempts, 1)
+		msg.Nack()
+		return errors.New("malformed payload")
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), []byte("garbage"))
+	if err := pubSub.Publish("poison", msg); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case deadLetter := <-deadLetters:
+		deadLetter.Ack()
+		if deadLetter.UUID != msg.UUID {
+			t.Errorf("expecting dead letter %s but got %s instead", msg.UUID, deadLetter.UUID)
+		}
+		want := map[string]string{
+			schema.DeadLetterOriginalTopic: "poison",
+			schema.DeadLetterError:         "malformed payload",
+			schema.DeadLetterAttempts:      "3",
+		}
+		for key, value := range want {
+			if got := deadLetter.Metadata.Get(key); got != value {
+				t.Errorf("expecting metadata %s to be %q but got %q instead", key, value, got)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expecting the message to be dead-lettered")
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("expecting 3 attempts but got %d instead", got)
+	}
+}
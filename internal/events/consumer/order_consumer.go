@@ -1,31 +1,62 @@
 package consumer
 
 import (
+	"context"
 	"log"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	common "github.com/muktiarafi/ticketing-common"
 	"github.com/muktiarafi/ticketing-common/types"
-	"github.com/muktiarafi/ticketing-orders/internal/constant"
 	"github.com/muktiarafi/ticketing-orders/internal/entity"
-	"github.com/muktiarafi/ticketing-orders/internal/events/producer"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
 	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
 )
 
+// OrderConsumer applies events from other services. Every message is
+// recorded in the processed messages ledger together with its side effects,
+// so a redelivered message is acked without being applied twice.
 type OrderConsumer struct {
-	producer.OrderProducer
-	repository.OrderRepository
-	repository.TicketRepository
+	service.TicketService
+	repository.ProcessedMessageRepository
+	repository.Transactor
+	service.OrderService
+	currency string
 }
 
-func NewOrderConsumer(producer producer.OrderProducer, orderRepo repository.OrderRepository, ticketRepo repository.TicketRepository) *OrderConsumer {
+func NewOrderConsumer(
+	ticketService service.TicketService,
+	processedMessageRepo repository.ProcessedMessageRepository,
+	transactor repository.Transactor,
+	orderService service.OrderService,
+	currency string,
+) *OrderConsumer {
 	return &OrderConsumer{
-		OrderProducer:    producer,
-		OrderRepository:  orderRepo,
-		TicketRepository: ticketRepo,
+		TicketService:              ticketService,
+		ProcessedMessageRepository: processedMessageRepo,
+		Transactor:                 transactor,
+		OrderService:               orderService,
+		currency:                   currency,
 	}
 }
 
+// once runs apply in a transaction unless msg was processed before.
+func (c *OrderConsumer) once(msg *message.Message, topic string, apply func(ctx context.Context) error) error {
+	return c.Transactor.WithinTransaction(msg.Context(), func(ctx context.Context) error {
+		fresh, err := c.ProcessedMessageRepository.Insert(ctx, msg.UUID, topic)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			log.Println("skipping already processed message:", msg.UUID)
+			return nil
+		}
+
+		return apply(ctx)
+	})
+}
+
 func (c *OrderConsumer) TicketCreated(msg *message.Message) error {
 	log.Println("received event from topic:", common.TicketCreated)
 	ticketCreatedData := new(types.TicketCreatedEvent)
@@ -34,13 +65,21 @@ func (c *OrderConsumer) TicketCreated(msg *message.Message) error {
 		return &common.Error{Op: "OrderConsumer.TicketCreated", Err: err}
 	}
 
+	price, err := money.FromFloat(ticketCreatedData.Price, c.currency)
+	if err != nil {
+		msg.Nack()
+		return &common.Error{Op: "OrderConsumer.TicketCreated", Err: err}
+	}
+
 	ticket := &entity.Ticket{
 		ID:    ticketCreatedData.ID,
 		Title: ticketCreatedData.Title,
-		Price: ticketCreatedData.Price,
+		Price: price,
 	}
 
-	if _, err := c.TicketRepository.Insert(ticket); err != nil {
+	if err := c.once(msg, common.TicketCreated, func(ctx context.Context) error {
+		return c.TicketService.Create(ctx, ticket)
+	}); err != nil {
 		msg.Nack()
 		return &common.Error{Op: "OrderConsumer.TicketCreated", Err: err}
 	}
@@ -58,20 +97,23 @@ func (c *OrderConsumer) TicketUpdated(msg *message.Message) error {
 		return &common.Error{Op: "OrderConsumer.TicketUpdated", Err: err}
 	}
 
+	price, err := money.FromFloat(ticketUpdatedData.Price, c.currency)
+	if err != nil {
+		msg.Nack()
+		return &common.Error{Op: "OrderConsumer.TicketUpdated", Err: err}
+	}
+
 	ticket := &entity.Ticket{
 		ID:      ticketUpdatedData.ID,
 		Title:   ticketUpdatedData.Title,
-		Price:   ticketUpdatedData.Price,
+		Price:   price,
 		Version: ticketUpdatedData.Version,
 	}
 
-	if _, err := c.TicketRepository.UpdateByEvent(ticket); err != nil {
-		er, _ := err.(*common.Error)
-		if er.Code == common.ECONCLICT {
-			msg.Ack()
-		} else {
-			msg.Nack()
-		}
+	if err := c.once(msg, common.TIcketUpdated, func(ctx context.Context) error {
+		return c.TicketService.Update(ctx, ticket)
+	}); err != nil {
+		msg.Nack()
 		return &common.Error{Op: "OrderConsumer.TicketUpdated", Err: err}
 	}
 
@@ -88,10 +130,11 @@ func (c *OrderConsumer) ExpirationComplete(msg *message.Message) error {
 		return err
 	}
 
-	order, err := c.OrderRepository.FindOne(expirationCompleteData.OrderID)
-	if err != nil {
-		er, _ := err.(*common.Error)
-		if er.Code == common.ENOTFOUND {
+	if err := c.once(msg, common.ExpirationComplete, func(ctx context.Context) error {
+		_, err := c.OrderService.Expire(ctx, expirationCompleteData.OrderID, msg.UUID)
+		return err
+	}); err != nil {
+		if common.ErrorCode(err) == common.ENOTFOUND {
 			msg.Ack()
 		} else {
 			msg.Nack()
@@ -99,19 +142,29 @@ func (c *OrderConsumer) ExpirationComplete(msg *message.Message) error {
 		return err
 	}
 
-	if order.Status == constant.COMPLETED {
-		msg.Ack()
-		return nil
-	}
+	msg.Ack()
+
+	return nil
+}
 
-	order.Status = constant.CANCELLED
-	order.Version++
-	if _, err := c.OrderRepository.Update(order); err != nil {
+func (c *OrderConsumer) PaymentCreated(msg *message.Message) error {
+	log.Println("received event from topic:", common.PaymentCreated)
+	paymentCreatedEventData := new(types.PaymentCreatedEvent)
+	if err := paymentCreatedEventData.Unmarshal(msg.Payload); err != nil {
 		msg.Nack()
 		return err
 	}
 
-	if err := c.OrderProducer.Cancelled(order); err != nil {
+	if err := c.once(msg, common.PaymentCreated, func(ctx context.Context) error {
+		_, err := c.OrderService.Pay(ctx, &entity.Payment{
+			ID:       paymentCreatedEventData.ID,
+			OrderID:  paymentCreatedEventData.OrderID,
+			StripeID: paymentCreatedEventData.StripeID,
+		}, msg.UUID)
+		return err
+	}); err != nil {
+		// payments for unknown orders or orders that cannot be completed are
+		// flagged by Pay rather than failing, so any error is worth a retry
 		msg.Nack()
 		return err
 	}
@@ -121,28 +174,26 @@ func (c *OrderConsumer) ExpirationComplete(msg *message.Message) error {
 	return nil
 }
 
-func (c *OrderConsumer) PaymentCreated(msg *message.Message) error {
-	log.Println("received event from topic:", common.PaymentCreated)
-	paymentCreatedEventData := new(types.PaymentCreatedEvent)
-	if err := paymentCreatedEventData.Unmarshal(msg.Payload); err != nil {
+func (c *OrderConsumer) RefundCompleted(msg *message.Message) error {
+	log.Println("received event from topic:", schema.RefundCompleted)
+	refundCompletedData := new(schema.RefundCompletedEvent)
+	if err := refundCompletedData.Unmarshal(msg.Payload); err != nil {
 		msg.Nack()
 		return err
 	}
 
-	order, err := c.OrderRepository.FindOne(paymentCreatedEventData.OrderID)
-	if err != nil {
-		er, _ := err.(*common.Error)
-		if er.Code == common.ENOTFOUND {
+	if err := c.once(msg, schema.RefundCompleted, func(ctx context.Context) error {
+		_, err := c.OrderService.Refund(ctx, refundCompletedData.OrderID, msg.UUID)
+		return err
+	}); err != nil {
+		switch {
+		case repository.IsVersionConflict(err):
+			msg.Nack()
+		case common.ErrorCode(err) == common.ENOTFOUND, common.ErrorCode(err) == common.ECONCLICT:
 			msg.Ack()
-		} else {
+		default:
 			msg.Nack()
 		}
-	}
-
-	order.Status = constant.COMPLETED
-	order.Version++
-	if _, err := c.OrderRepository.Update(order); err != nil {
-		msg.Nack()
 		return err
 	}
 
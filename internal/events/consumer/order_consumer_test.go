@@ -0,0 +1,297 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-common/types"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/events/outbox"
+	"github.com/muktiarafi/ticketing-orders/internal/events/producer"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+	"github.com/muktiarafi/ticketing-orders/internal/sweeper"
+	"github.com/muktiarafi/ticketing-orders/internal/testutil"
+)
+
+func newTestOrderConsumer() (*OrderConsumer, service.TicketService) {
+	setupDatabase()
+	ticketService := service.NewTicketService(
+		ticketRepo,
+		repository.NewParkedTicketUpdateRepository(db),
+		producer.NewTicketProducer(outbox.NewPublisher(repository.NewOutboxRepository(db))),
+		transactor,
+		testClock,
+	)
+	orderConsumer := NewOrderConsumer(
+		ticketService,
+		repository.NewProcessedMessageRepository(db),
+		transactor,
+		orderService,
+		"USD",
+	)
+
+	return orderConsumer, ticketService
+}
+
+// deliver hands payload to handle and fails unless the message is acked.
+func deliver(t *testing.T, handle func(*message.Message) error, payload []byte) {
+	t.Helper()
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	if err := handle(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-msg.Acked():
+	default:
+		t.Error("expecting message to be acked")
+	}
+}
+
+// placeOrder creates a ticket through orderConsumer, as the tickets service
+// would, and orders it for userID.
+func placeOrder(t *testing.T, orderConsumer *OrderConsumer, userID int64) *entity.Order {
+	t.Helper()
+	ticketCreatedData := &types.TicketCreatedEvent{ID: testutil.NextTicketID(), Version: 0, Title: "ticket", Price: 12}
+	payload, _ := ticketCreatedData.Marshal()
+	deliver(t, orderConsumer.TicketCreated, payload)
+
+	order, err := orderService.Create(context.Background(), userID, &model.OrderDTO{TicketID: ticketCreatedData.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return order
+}
+
+func TestOrderConsumerRedelivery(t *testing.T) {
+	orderConsumer, _ := newTestOrderConsumer()
+	deliverTwice := func(t *testing.T, handle func(*message.Message) error, payload []byte) {
+		t.Helper()
+		uuid := watermill.NewUUID()
+		for i := 0; i < 2; i++ {
+			msg := message.NewMessage(uuid, payload)
+			if err := handle(msg); err != nil {
+				t.Fatalf("delivery %d: %v", i+1, err)
+			}
+
+			select {
+			case <-msg.Acked():
+			default:
+				t.Errorf("expecting delivery %d to be acked", i+1)
+			}
+		}
+	}
+
+	t.Run("redelivered ticket created event", func(t *testing.T) {
+		ticketCreatedData := &types.TicketCreatedEvent{ID: testutil.NextTicketID(), Version: 0, Title: "ticket", Price: 12}
+		payload, _ := ticketCreatedData.Marshal()
+
+		deliverTwice(t, orderConsumer.TicketCreated, payload)
+
+		if _, err := ticketRepo.FindOne(context.Background(), ticketCreatedData.ID); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("redelivered expiration complete event", func(t *testing.T) {
+		order := placeOrder(t, orderConsumer, 18)
+		outboxCount := countOutboxMessages(t, common.OrderCancelled)
+		expirationCompleteData := &types.ExpirationCompleteEvent{OrderID: order.ID}
+		payload, _ := expirationCompleteData.Marshal()
+
+		deliverTwice(t, orderConsumer.ExpirationComplete, payload)
+
+		if got := countOutboxMessages(t, common.OrderCancelled); got != outboxCount+1 {
+			t.Errorf("expecting one order cancelled event, got %d messages instead of %d", got, outboxCount+1)
+		}
+	})
+}
+
+func TestOrderConsumerTicketUpdates(t *testing.T) {
+	orderConsumer, ticketService := newTestOrderConsumer()
+	deliverUpdate := func(t *testing.T, ticketID, version int64, title string) {
+		t.Helper()
+		ticketUpdatedData := &types.TicketUpdatedEvent{ID: ticketID, Version: version, Title: title, Price: 12}
+		payload, _ := ticketUpdatedData.Marshal()
+		if err := orderConsumer.TicketUpdated(message.NewMessage(watermill.NewUUID(), payload)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertTicket := func(t *testing.T, ticketID, version int64, title string) {
+		t.Helper()
+		ticket, err := ticketRepo.FindOne(context.Background(), ticketID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ticket.Version != version || ticket.Title != title {
+			t.Errorf("expecting ticket %d to be %q at version %d but got %q at version %d instead", ticketID, title, version, ticket.Title, ticket.Version)
+		}
+	}
+
+	t.Run("update arriving before its predecessor", func(t *testing.T) {
+		ticketID := testutil.InsertTicket(t, ticketRepo, "v0", money.New(1200, "USD")).ID
+
+		deliverUpdate(t, ticketID, 2, "v2")
+		assertTicket(t, ticketID, 0, "v0")
+
+		deliverUpdate(t, ticketID, 1, "v1")
+		assertTicket(t, ticketID, 2, "v2")
+	})
+
+	t.Run("update arriving before the ticket", func(t *testing.T) {
+		ticketID := testutil.NextTicketID()
+		deliverUpdate(t, ticketID, 1, "v1")
+
+		if err := ticketService.Create(context.Background(), &entity.Ticket{ID: ticketID, Title: "v0", Price: money.New(1200, "USD")}); err != nil {
+			t.Fatal(err)
+		}
+		assertTicket(t, ticketID, 1, "v1")
+	})
+
+	t.Run("gap that never fills", func(t *testing.T) {
+		ticketID := testutil.InsertTicket(t, ticketRepo, "v0", money.New(1200, "USD")).ID
+		deliverUpdate(t, ticketID, 3, "v3")
+		outboxCount := countOutboxMessages(t, schema.TicketResyncRequested)
+
+		future := testutil.FixedClock(testClock.Now().Add(time.Hour))
+		resyncer := sweeper.NewTicketResyncer(repository.NewParkedTicketUpdateRepository(db), transactor, ticketService, future, time.Second, time.Minute, 100)
+		if err := resyncer.Sweep(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		assertTicket(t, ticketID, 3, "v3")
+		if got := countOutboxMessages(t, schema.TicketResyncRequested); got != outboxCount+1 {
+			t.Errorf("expecting a resync to be requested, got %d messages instead of %d", got, outboxCount+1)
+		}
+	})
+
+	t.Run("creation arriving after a resync", func(t *testing.T) {
+		ticketID := testutil.NextTicketID()
+		deliverUpdate(t, ticketID, 2, "v2")
+		if err := ticketService.Resync(context.Background(), ticketID); err != nil {
+			t.Fatal(err)
+		}
+
+		ticketCreatedData := &types.TicketCreatedEvent{ID: ticketID, Version: 0, Title: "v0", Price: 12}
+		payload, _ := ticketCreatedData.Marshal()
+		deliver(t, orderConsumer.TicketCreated, payload)
+
+		assertTicket(t, ticketID, 2, "v2")
+	})
+}
+
+func TestOrderConsumerPayments(t *testing.T) {
+	orderConsumer, _ := newTestOrderConsumer()
+	pay := func(t *testing.T, paymentID, orderID int64) {
+		t.Helper()
+		paymentCreatedData := &types.PaymentCreatedEvent{ID: paymentID, StripeID: "ch_test", OrderID: orderID}
+		payload, _ := paymentCreatedData.Marshal()
+		deliver(t, orderConsumer.PaymentCreated, payload)
+	}
+	assertStatus := func(t *testing.T, orderID int64, want string) {
+		t.Helper()
+		order, err := orderRepo.FindOne(context.Background(), orderID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.Status != want {
+			t.Errorf("expecting order status %s, got %s", want, order.Status)
+		}
+	}
+
+	t.Run("payment for an expired order is flagged", func(t *testing.T) {
+		order := placeOrder(t, orderConsumer, 19)
+		expirationCompleteData := &types.ExpirationCompleteEvent{OrderID: order.ID}
+		payload, _ := expirationCompleteData.Marshal()
+		deliver(t, orderConsumer.ExpirationComplete, payload)
+		mismatchCount := countOutboxMessages(t, schema.OrderPaymentMismatch)
+
+		pay(t, 9001, order.ID)
+
+		assertStatus(t, order.ID, constant.CANCELLED)
+		if got := countOutboxMessages(t, schema.OrderPaymentMismatch); got != mismatchCount+1 {
+			t.Errorf("expecting one payment mismatch event, got %d messages instead of %d", got, mismatchCount+1)
+		}
+	})
+
+	t.Run("payment for an unknown order is flagged", func(t *testing.T) {
+		mismatchCount := countOutboxMessages(t, schema.OrderPaymentMismatch)
+
+		pay(t, 9005, 999999)
+
+		if got := countOutboxMessages(t, schema.OrderPaymentMismatch); got != mismatchCount+1 {
+			t.Errorf("expecting one payment mismatch event, got %d messages instead of %d", got, mismatchCount+1)
+		}
+		var status string
+		stmt := `SELECT status FROM order_payments WHERE payment_id = $1`
+		if err := db.SQL.QueryRow(stmt, 9005).Scan(&status); err != nil {
+			t.Fatal(err)
+		}
+		if status != constant.PAYMENT_MISMATCHED {
+			t.Errorf("expecting a mismatched payment, got status %s", status)
+		}
+	})
+
+	t.Run("second payment for a completed order is flagged once", func(t *testing.T) {
+		order := placeOrder(t, orderConsumer, 19)
+		mismatchCount := countOutboxMessages(t, schema.OrderPaymentMismatch)
+
+		pay(t, 9002, order.ID)
+		assertStatus(t, order.ID, constant.COMPLETED)
+		if got := countOutboxMessages(t, schema.OrderPaymentMismatch); got != mismatchCount {
+			t.Errorf("expecting no payment mismatch event, got %d messages instead of %d", got, mismatchCount)
+		}
+
+		pay(t, 9003, order.ID)
+		pay(t, 9003, order.ID)
+
+		assertStatus(t, order.ID, constant.COMPLETED)
+		if got := countOutboxMessages(t, schema.OrderPaymentMismatch); got != mismatchCount+1 {
+			t.Errorf("expecting one payment mismatch event, got %d messages instead of %d", got, mismatchCount+1)
+		}
+	})
+}
+
+func TestOrderConsumerStatusEvents(t *testing.T) {
+	orderConsumer, _ := newTestOrderConsumer()
+	updatedCount := countOutboxMessages(t, schema.OrderUpdated)
+	completedCount := countOutboxMessages(t, schema.OrderCompleted)
+
+	order := placeOrder(t, orderConsumer, 20)
+	paymentCreatedData := &types.PaymentCreatedEvent{ID: 9004, StripeID: "ch_test", OrderID: order.ID}
+	payload, _ := paymentCreatedData.Marshal()
+	deliver(t, orderConsumer.PaymentCreated, payload)
+
+	if got := countOutboxMessages(t, schema.OrderCompleted); got != completedCount+1 {
+		t.Errorf("expecting one order completed event, got %d messages instead of %d", got, completedCount+1)
+	}
+	if got := countOutboxMessages(t, schema.OrderUpdated); got != updatedCount+2 {
+		t.Errorf("expecting an order updated event for creation and completion, got %d messages instead of %d", got, updatedCount+2)
+	}
+
+	var updatedPayload []byte
+	stmt := `SELECT payload FROM outbox WHERE topic = $1 ORDER BY id DESC LIMIT 1`
+	if err := db.SQL.QueryRow(stmt, schema.OrderUpdated).Scan(&updatedPayload); err != nil {
+		t.Fatal(err)
+	}
+	orderUpdatedData := new(schema.OrderUpdatedEvent)
+	if err := orderUpdatedData.Unmarshal(updatedPayload); err != nil {
+		t.Fatal(err)
+	}
+	if orderUpdatedData.ID != order.ID || orderUpdatedData.Status != constant.COMPLETED || orderUpdatedData.PreviousStatus != constant.CREATED {
+		t.Errorf("expecting order %d changed from CREATED to COMPLETED, got %+v", order.ID, orderUpdatedData)
+	}
+	if orderUpdatedData.Version != order.Version+1 {
+		t.Errorf("expecting version %d, got %d", order.Version+1, orderUpdatedData.Version)
+	}
+}
@@ -0,0 +1,54 @@
+package consumer
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/config"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+	"github.com/muktiarafi/ticketing-orders/internal/testutil"
+)
+
+var ticketRepo repository.TicketRepository
+var orderRepo repository.OrderRepository
+var transactor repository.Transactor
+var orderService service.OrderService
+var db *driver.DB
+
+var testClock = testutil.FixedClock(time.Now().UTC().Truncate(time.Second))
+
+var setupOnce sync.Once
+var purge func()
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	if purge != nil {
+		purge()
+	}
+
+	os.Exit(code)
+}
+
+// setupDatabase starts the database on first use, so the tests that don't
+// need one, like the dispatcher's, run without docker.
+func setupDatabase() {
+	setupOnce.Do(func() {
+		db, purge = testutil.NewDatabase()
+
+		ticketRepo = repository.NewTicketRepository(db)
+		orderRepo = repository.NewOrderRepository(db)
+		transactor = repository.NewTransactor(db)
+		orderService = testutil.NewOrderService(db, &config.Limits{}, &config.Expiration{Default: time.Minute}, testClock)
+	})
+}
+
+func countOutboxMessages(t testing.TB, topic string) int {
+	t.Helper()
+
+	return testutil.CountOutboxMessages(t, db, topic)
+}
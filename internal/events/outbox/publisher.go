@@ -0,0 +1,41 @@
+package outbox
+
+import (
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+)
+
+// Publisher is a message.Publisher that stores messages in the outbox table
+// instead of sending them to the broker. The Relay forwards them afterwards.
+// Messages are written with their own context, so a message whose context
+// carries a transaction is only stored if that transaction commits.
+type Publisher struct {
+	repository.OutboxRepository
+}
+
+func NewPublisher(outboxRepo repository.OutboxRepository) *Publisher {
+	return &Publisher{
+		OutboxRepository: outboxRepo,
+	}
+}
+
+func (p *Publisher) Publish(topic string, messages ...*message.Message) error {
+	for _, msg := range messages {
+		outboxMessage := &entity.OutboxMessage{
+			UUID:     msg.UUID,
+			Topic:    topic,
+			Payload:  msg.Payload,
+			Metadata: msg.Metadata,
+		}
+		if err := p.OutboxRepository.Insert(msg.Context(), outboxMessage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	return nil
+}
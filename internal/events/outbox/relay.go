@@ -0,0 +1,108 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+)
+
+const maxRetryDelay = 5 * time.Minute
+
+// Relay forwards pending outbox rows to the broker. Replicas take turns
+// through an advisory lock, and a row is only marked as published after the
+// broker accepted it, which gives at-least-once delivery. Messages of one
+// topic are sent in the order they were written: once one fails, the rest of
+// its topic waits for the retry.
+type Relay struct {
+	repository.OutboxRepository
+	repository.Transactor
+	message.Publisher
+	interval  time.Duration
+	batchSize int
+}
+
+func NewRelay(
+	outboxRepo repository.OutboxRepository,
+	transactor repository.Transactor,
+	publisher message.Publisher,
+	interval time.Duration,
+	batchSize int,
+) *Relay {
+	return &Relay{
+		OutboxRepository: outboxRepo,
+		Transactor:       transactor,
+		Publisher:        publisher,
+		interval:         interval,
+		batchSize:        batchSize,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.RelayPending(ctx); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+func (r *Relay) RelayPending(ctx context.Context) error {
+	return r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		locked, err := r.OutboxRepository.Lock(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		pending, err := r.OutboxRepository.FindPending(ctx, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		failedTopics := make(map[string]bool)
+		for _, outboxMessage := range pending {
+			if failedTopics[outboxMessage.Topic] {
+				continue
+			}
+
+			msg := message.NewMessage(outboxMessage.UUID, outboxMessage.Payload)
+			msg.Metadata = outboxMessage.Metadata
+
+			if err := r.Publisher.Publish(outboxMessage.Topic, msg); err != nil {
+				log.Printf("failed to relay outbox message %s: %v", outboxMessage.UUID, err)
+				failedTopics[outboxMessage.Topic] = true
+				if err := r.OutboxRepository.MarkFailed(ctx, outboxMessage.ID, r.retryDelay(outboxMessage.Attempts), err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := r.OutboxRepository.MarkPublished(ctx, outboxMessage.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *Relay) retryDelay(attempts int) time.Duration {
+	delay := r.interval
+	for i := 0; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	return delay
+}
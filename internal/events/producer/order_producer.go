@@ -1,8 +1,31 @@
 package producer
 
-import "github.com/muktiarafi/ticketing-orders/internal/entity"
+import (
+	"context"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+// Metadata keys of order events, for data the shared event types have no
+// room for.
+const (
+	// OrderItemCountMetadataKey tells consumers how many events make up one
+	// order, as order events are published once per item.
+	OrderItemCountMetadataKey = "order_item_count"
+	// CurrencyMetadataKey holds the currency of the price in OrderCreatedEvent.
+	CurrencyMetadataKey = "currency"
+	// OrderTotalMetadataKey holds the order total, in major units, on every
+	// OrderCreatedEvent of the order.
+	OrderTotalMetadataKey         = "order_total"
+	CancellationReasonMetadataKey = "cancellation_reason"
+	CancellationNoteMetadataKey   = "cancellation_note"
+)
 
 type OrderProducer interface {
-	Created(order *entity.Order) error
-	Cancelled(order *entity.Order) error
+	Created(ctx context.Context, order *entity.Order) error
+	Cancelled(ctx context.Context, order *entity.Order) error
+	Completed(ctx context.Context, order *entity.Order) error
+	Updated(ctx context.Context, order *entity.Order, change *entity.OrderEvent) error
+	RefundRequested(ctx context.Context, order *entity.Order) error
+	PaymentMismatch(ctx context.Context, order *entity.Order, payment *entity.Payment) error
 }
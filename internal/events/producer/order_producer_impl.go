@@ -1,6 +1,8 @@
 package producer
 
 import (
+	"context"
+	"strconv"
 	"time"
 
 	"github.com/ThreeDotsLabs/watermill"
@@ -8,8 +10,12 @@ import (
 	common "github.com/muktiarafi/ticketing-common"
 	"github.com/muktiarafi/ticketing-common/types"
 	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
 )
 
+// OrderProducerImpl attaches ctx to every message, so when publisher is an
+// outbox.Publisher the event is committed together with the surrounding
+// transaction.
 type OrderProducerImpl struct {
 	message.Publisher
 }
@@ -20,36 +26,177 @@ func NewOrderProducer(publisher message.Publisher) OrderProducer {
 	}
 }
 
-func (p *OrderProducerImpl) Created(order *entity.Order) error {
-	orderCreatedEventData := types.OrderCreatedEvent{
-		ID:          order.ID,
-		Status:      order.Status,
-		Version:     order.Version,
-		UserID:      order.UserID,
-		ExpiresAt:   order.ExpiresAt.Format(time.RFC3339),
-		TicketID:    order.Ticket.ID,
-		TicketPrice: order.Ticket.Price,
+// Created publishes one OrderCreatedEvent per item, since the event carries a
+// single ticket. Each event carries the price its ticket was reserved at, as
+// snapshotted on the order item rather than the current ticket price, and the
+// order total travels in the message metadata.
+func (p *OrderProducerImpl) Created(ctx context.Context, order *entity.Order) error {
+	msgs := make([]*message.Message, 0, len(order.Items))
+	for _, item := range order.Items {
+		orderCreatedEventData := types.OrderCreatedEvent{
+			ID:          order.ID,
+			Status:      order.Status,
+			Version:     order.Version,
+			UserID:      order.UserID,
+			ExpiresAt:   order.ExpiresAt.UTC().Format(time.RFC3339),
+			TicketID:    item.Ticket.ID,
+			TicketPrice: item.UnitPrice.Float64(),
+		}
+		orderBytes, err := orderCreatedEventData.Marshal()
+		if err != nil {
+			return &common.Error{Op: "OrderProducer.Created", Err: err}
+		}
+
+		msg := p.newMessage(ctx, orderBytes, len(order.Items))
+		msg.Metadata.Set(CurrencyMetadataKey, item.UnitPrice.Currency)
+		msg.Metadata.Set(OrderTotalMetadataKey, order.Total.Decimal())
+		msgs = append(msgs, msg)
+	}
+
+	return p.Publish(common.OrderCreated, msgs...)
+}
+
+// Cancelled publishes one OrderCancelledEvent per item so that every ticket of
+// the order is released. The event has no room for the cancellation reason,
+// so it travels in the message metadata.
+func (p *OrderProducerImpl) Cancelled(ctx context.Context, order *entity.Order) error {
+	msgs := make([]*message.Message, 0, len(order.Items))
+	for _, item := range order.Items {
+		orderCancelledData := types.OrderCancelledEvent{
+			ID:       order.ID,
+			Version:  order.Version,
+			TicketID: item.Ticket.ID,
+		}
+		orderBytes, err := orderCancelledData.Marshal()
+		if err != nil {
+			return &common.Error{Op: "OrderProducer.Cancelled", Err: err}
+		}
+
+		msg := p.newMessage(ctx, orderBytes, len(order.Items))
+		if order.Cancellation != nil {
+			msg.Metadata.Set(CancellationReasonMetadataKey, order.Cancellation.ReasonCode)
+			msg.Metadata.Set(CancellationNoteMetadataKey, order.Cancellation.Note)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return p.Publish(common.OrderCancelled, msgs...)
+}
+
+// Completed publishes one OrderCompletedEvent per item so that every ticket of
+// the order can be marked as sold. Each event carries the amount of its own
+// item.
+func (p *OrderProducerImpl) Completed(ctx context.Context, order *entity.Order) error {
+	msgs := make([]*message.Message, 0, len(order.Items))
+	for _, item := range order.Items {
+		orderCompletedData := schema.OrderCompletedEvent{
+			ID:       order.ID,
+			Version:  order.Version,
+			UserID:   order.UserID,
+			TicketID: item.Ticket.ID,
+			Amount:   item.UnitPrice.Mul(int64(item.Quantity)).Amount,
+			Currency: item.UnitPrice.Currency,
+		}
+		orderBytes, err := orderCompletedData.Marshal()
+		if err != nil {
+			return &common.Error{Op: "OrderProducer.Completed", Err: err}
+		}
+
+		msgs = append(msgs, p.newMessage(ctx, orderBytes, len(order.Items)))
+	}
+
+	return p.Publish(schema.OrderCompleted, msgs...)
+}
+
+// Updated publishes the state of the order after the status change recorded
+// in change, as a single event for the whole order.
+func (p *OrderProducerImpl) Updated(ctx context.Context, order *entity.Order, change *entity.OrderEvent) error {
+	orderUpdatedData := schema.OrderUpdatedEvent{
+		ID:             order.ID,
+		Status:         order.Status,
+		PreviousStatus: change.FromStatus,
+		Version:        order.Version,
+		UserID:         order.UserID,
+		ExpiresAt:      order.ExpiresAt.UTC().Format(time.RFC3339),
+		CreatedAt:      order.CreatedAt.UTC().Format(time.RFC3339),
+		Total:          order.Total.Amount,
+		Currency:       order.Total.Currency,
+		Items:          make([]*schema.OrderUpdatedItem, 0, len(order.Items)),
+		Change: &schema.OrderUpdatedStatusInfo{
+			ActorType: change.ActorType,
+			ActorID:   change.ActorID,
+			Reason:    change.Reason,
+			ChangedAt: change.CreatedAt.UTC().Format(time.RFC3339),
+		},
+	}
+	for _, item := range order.Items {
+		orderUpdatedData.Items = append(orderUpdatedData.Items, &schema.OrderUpdatedItem{
+			TicketID:  item.Ticket.ID,
+			Title:     item.Title,
+			Quantity:  int64(item.Quantity),
+			UnitPrice: item.UnitPrice.Amount,
+		})
 	}
-	orderBytes, err := orderCreatedEventData.Marshal()
+	if order.Cancellation != nil {
+		orderUpdatedData.CancellationReason = order.Cancellation.ReasonCode
+		orderUpdatedData.CancellationNote = order.Cancellation.Note
+	}
+	orderBytes, err := orderUpdatedData.Marshal()
 	if err != nil {
-		return &common.Error{Op: "OrderProducer.Created", Err: err}
+		return &common.Error{Op: "OrderProducer.Updated", Err: err}
 	}
 
-	msg := message.NewMessage(watermill.NewUUID(), orderBytes)
-	return p.Publish(common.OrderCreated, msg)
+	return p.Publish(schema.OrderUpdated, p.newMessage(ctx, orderBytes, len(order.Items)))
 }
 
-func (p *OrderProducerImpl) Cancelled(order *entity.Order) error {
-	orderCancelledData := types.OrderCancelledEvent{
+func (p *OrderProducerImpl) RefundRequested(ctx context.Context, order *entity.Order) error {
+	refundRequestedData := schema.OrderRefundRequestedEvent{
 		ID:       order.ID,
 		Version:  order.Version,
-		TicketID: order.Ticket.ID,
+		UserID:   order.UserID,
+		Amount:   order.Total.Amount,
+		Currency: order.Total.Currency,
+	}
+	if order.Cancellation != nil {
+		refundRequestedData.ReasonCode = order.Cancellation.ReasonCode
+	}
+	orderBytes, err := refundRequestedData.Marshal()
+	if err != nil {
+		return &common.Error{Op: "OrderProducer.RefundRequested", Err: err}
+	}
+
+	return p.Publish(schema.OrderRefundRequested, p.newMessage(ctx, orderBytes, len(order.Items)))
+}
+
+// PaymentMismatch flags payment for a refund. order is nil when the payment
+// names an unknown order.
+func (p *OrderProducerImpl) PaymentMismatch(ctx context.Context, order *entity.Order, payment *entity.Payment) error {
+	mismatchData := schema.OrderPaymentMismatchEvent{
+		OrderID:   payment.OrderID,
+		PaymentID: payment.ID,
+		StripeID:  payment.StripeID,
+		Reason:    payment.Reason,
+	}
+	itemCount := 0
+	if order != nil {
+		mismatchData.OrderStatus = order.Status
+		mismatchData.UserID = order.UserID
+		mismatchData.OrderTotal = order.Total.Amount
+		mismatchData.Currency = order.Total.Currency
+		itemCount = len(order.Items)
 	}
-	orderBytes, err := orderCancelledData.Marshal()
+	mismatchBytes, err := mismatchData.Marshal()
 	if err != nil {
-		return &common.Error{Op: "OrderProducer.Cancelled", Err: err}
+		return &common.Error{Op: "OrderProducer.PaymentMismatch", Err: err}
 	}
 
-	msg := message.NewMessage(watermill.NewUUID(), orderBytes)
-	return p.Publish(common.OrderCancelled, msg)
+	return p.Publish(schema.OrderPaymentMismatch, p.newMessage(ctx, mismatchBytes, itemCount))
+}
+
+func (p *OrderProducerImpl) newMessage(ctx context.Context, payload []byte, itemCount int) *message.Message {
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set(OrderItemCountMetadataKey, strconv.Itoa(itemCount))
+	msg.SetContext(ctx)
+
+	return msg
 }
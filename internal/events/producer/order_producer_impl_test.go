@@ -0,0 +1,67 @@
+package producer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
+)
+
+type recordingPublisher struct {
+	topic string
+	msgs  []*message.Message
+}
+
+func (p *recordingPublisher) Publish(topic string, msgs ...*message.Message) error {
+	p.topic = topic
+	p.msgs = append(p.msgs, msgs...)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
+
+func TestOrderProducerCompleted(t *testing.T) {
+	publisher := &recordingPublisher{}
+	order := &entity.Order{
+		ID:     1,
+		UserID: 2,
+		Total:  money.New(4500, "USD"),
+		Items: []*entity.OrderItem{
+			{Quantity: 2, UnitPrice: money.New(1500, "USD"), Ticket: &entity.Ticket{ID: 10}},
+			{Quantity: 1, UnitPrice: money.New(1500, "USD"), Ticket: &entity.Ticket{ID: 11}},
+		},
+	}
+
+	if err := NewOrderProducer(publisher).Completed(context.Background(), order); err != nil {
+		t.Fatal(err)
+	}
+
+	if publisher.topic != schema.OrderCompleted {
+		t.Errorf("expecting topic %s but got %s instead", schema.OrderCompleted, publisher.topic)
+	}
+	if len(publisher.msgs) != len(order.Items) {
+		t.Fatalf("expecting %d events but got %d instead", len(order.Items), len(publisher.msgs))
+	}
+
+	var sum int64
+	for i, msg := range publisher.msgs {
+		orderCompletedData := new(schema.OrderCompletedEvent)
+		if err := orderCompletedData.Unmarshal(msg.Payload); err != nil {
+			t.Fatal(err)
+		}
+		item := order.Items[i]
+		want := item.UnitPrice.Mul(int64(item.Quantity)).Amount
+		if orderCompletedData.TicketID != item.Ticket.ID || orderCompletedData.Amount != want {
+			t.Errorf("expecting ticket %d at %d but got ticket %d at %d instead", item.Ticket.ID, want, orderCompletedData.TicketID, orderCompletedData.Amount)
+		}
+		sum += orderCompletedData.Amount
+	}
+	if sum != order.Total.Amount {
+		t.Errorf("expecting the amounts to add up to %d but got %d instead", order.Total.Amount, sum)
+	}
+}
@@ -0,0 +1,7 @@
+package producer
+
+import "context"
+
+type TicketProducer interface {
+	ResyncRequested(ctx context.Context, ticketID, missingFrom, missingTo int64) error
+}
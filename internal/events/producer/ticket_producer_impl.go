@@ -0,0 +1,36 @@
+package producer
+
+import (
+	"context"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+)
+
+type TicketProducerImpl struct {
+	message.Publisher
+}
+
+func NewTicketProducer(publisher message.Publisher) TicketProducer {
+	return &TicketProducerImpl{
+		Publisher: publisher,
+	}
+}
+
+func (p *TicketProducerImpl) ResyncRequested(ctx context.Context, ticketID, missingFrom, missingTo int64) error {
+	resyncData := schema.TicketResyncRequestedEvent{
+		TicketID:    ticketID,
+		MissingFrom: missingFrom,
+		MissingTo:   missingTo,
+	}
+	resyncBytes, err := resyncData.Marshal()
+	if err != nil {
+		return &common.Error{Op: "TicketProducer.ResyncRequested", Err: err}
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), resyncBytes)
+	msg.SetContext(ctx)
+	return p.Publish(schema.TicketResyncRequested, msg)
+}
@@ -0,0 +1,11 @@
+package producer
+
+import (
+	"context"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type WaitlistProducer interface {
+	Promoted(ctx context.Context, entry *entity.WaitlistEntry, order *entity.Order) error
+}
@@ -0,0 +1,40 @@
+package producer
+
+import (
+	"context"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+)
+
+type WaitlistProducerImpl struct {
+	message.Publisher
+}
+
+func NewWaitlistProducer(publisher message.Publisher) WaitlistProducer {
+	return &WaitlistProducerImpl{
+		Publisher: publisher,
+	}
+}
+
+func (p *WaitlistProducerImpl) Promoted(ctx context.Context, entry *entity.WaitlistEntry, order *entity.Order) error {
+	promotedData := schema.WaitlistPromotedEvent{
+		EntryID:   entry.ID,
+		TicketID:  entry.TicketID,
+		UserID:    entry.UserID,
+		OrderID:   order.ID,
+		ExpiresAt: order.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+	promotedBytes, err := promotedData.Marshal()
+	if err != nil {
+		return &common.Error{Op: "WaitlistProducer.Promoted", Err: err}
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), promotedBytes)
+	msg.SetContext(ctx)
+	return p.Publish(schema.WaitlistPromoted, msg)
+}
@@ -0,0 +1,16 @@
+package schema
+
+// Metadata set on messages moved to a dead-letter topic.
+const (
+	DeadLetterOriginalTopic = "dlq_original_topic"
+	DeadLetterHandler       = "dlq_handler"
+	DeadLetterError         = "dlq_error"
+	DeadLetterAttempts      = "dlq_attempts"
+	DeadLetterFailedAt      = "dlq_failed_at"
+)
+
+// DeadLetterTopic is where messages of topic go once their handler gave up
+// on them.
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
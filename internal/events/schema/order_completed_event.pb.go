@@ -0,0 +1,501 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: order_completed_event.proto
+
+package schema
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// OrderCompletedEvent tells other services that an order has been paid. Like
+// the shared order events it carries a single ticket, so one is published per
+// item. Amount is what that item cost, in minor units of currency, so the
+// amounts of an order add up to its total.
+type OrderCompletedEvent struct {
+	ID       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version  int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	UserID   int64  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TicketID int64  `protobuf:"varint,4,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	Amount   int64  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency string `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (m *OrderCompletedEvent) Reset()         { *m = OrderCompletedEvent{} }
+func (m *OrderCompletedEvent) String() string { return proto.CompactTextString(m) }
+func (*OrderCompletedEvent) ProtoMessage()    {}
+func (*OrderCompletedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6549fdc16093bf3, []int{0}
+}
+func (m *OrderCompletedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OrderCompletedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OrderCompletedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OrderCompletedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderCompletedEvent.Merge(m, src)
+}
+func (m *OrderCompletedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *OrderCompletedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderCompletedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderCompletedEvent proto.InternalMessageInfo
+
+func (m *OrderCompletedEvent) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *OrderCompletedEvent) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *OrderCompletedEvent) GetUserID() int64 {
+	if m != nil {
+		return m.UserID
+	}
+	return 0
+}
+
+func (m *OrderCompletedEvent) GetTicketID() int64 {
+	if m != nil {
+		return m.TicketID
+	}
+	return 0
+}
+
+func (m *OrderCompletedEvent) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *OrderCompletedEvent) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*OrderCompletedEvent)(nil), "schema.OrderCompletedEvent")
+}
+
+func init() { proto.RegisterFile("order_completed_event.proto", fileDescriptor_e6549fdc16093bf3) }
+
+var fileDescriptor_e6549fdc16093bf3 = []byte{
+	// 270 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x3c, 0x8f, 0xb1, 0x4e, 0xeb, 0x30,
+	0x18, 0x85, 0xe3, 0xf4, 0x5e, 0x37, 0xb5, 0x98, 0x8c, 0x54, 0x59, 0x45, 0x72, 0x2a, 0x58, 0xca,
+	0x40, 0x3b, 0xf0, 0x06, 0x25, 0x0c, 0x99, 0x90, 0x22, 0x98, 0xa3, 0xc6, 0xfe, 0x49, 0x2d, 0x48,
+	0x5c, 0x39, 0x4e, 0x25, 0xde, 0x82, 0xc7, 0x42, 0x62, 0xe9, 0xc8, 0x14, 0x21, 0xe7, 0x45, 0x50,
+	0x5c, 0xca, 0xe6, 0xcf, 0xdf, 0x39, 0xc7, 0x32, 0xb9, 0xd0, 0x46, 0x82, 0xc9, 0x85, 0xae, 0x76,
+	0xaf, 0x60, 0x41, 0xe6, 0xb0, 0x87, 0xda, 0x2e, 0x77, 0x46, 0x5b, 0x4d, 0x71, 0x23, 0xb6, 0x50,
+	0x6d, 0x66, 0x37, 0xa5, 0xb2, 0xdb, 0xb6, 0x58, 0x0a, 0x5d, 0xad, 0x4a, 0x5d, 0xea, 0x95, 0xd7,
+	0x45, 0xfb, 0xec, 0xc9, 0x83, 0x3f, 0x1d, 0x6b, 0x97, 0x9f, 0x88, 0x9c, 0x3f, 0x0c, 0xb3, 0x77,
+	0xa7, 0xd5, 0xfb, 0x61, 0x94, 0x4e, 0x49, 0xa8, 0x24, 0x43, 0x73, 0xb4, 0x18, 0xad, 0xb1, 0xeb,
+	0xe2, 0x30, 0x4d, 0xb2, 0x50, 0x49, 0xca, 0xc8, 0x78, 0x0f, 0xa6, 0x51, 0xba, 0x66, 0xe1, 0x20,
+	0xb3, 0x13, 0xd2, 0x2b, 0x32, 0x6e, 0x1b, 0x30, 0xb9, 0x92, 0x6c, 0xe4, 0x6b, 0xc4, 0x75, 0x31,
+	0x7e, 0x6a, 0xc0, 0xa4, 0x49, 0x86, 0x07, 0x95, 0x4a, 0x7a, 0x4d, 0x26, 0x56, 0x89, 0x17, 0xb0,
+	0x43, 0xec, 0x9f, 0x8f, 0x9d, 0xb9, 0x2e, 0x8e, 0x1e, 0xfd, 0x65, 0x9a, 0x64, 0xd1, 0x51, 0xa7,
+	0x92, 0x4e, 0x09, 0xde, 0x54, 0xba, 0xad, 0x2d, 0xfb, 0xef, 0x1f, 0xfa, 0x25, 0x3a, 0x23, 0x91,
+	0x68, 0x8d, 0x81, 0x5a, 0xbc, 0x31, 0x3c, 0x47, 0x8b, 0x49, 0xf6, 0xc7, 0x6b, 0xf6, 0xe1, 0x38,
+	0x3a, 0x38, 0x8e, 0xbe, 0x1d, 0x47, 0xef, 0x3d, 0x0f, 0x0e, 0x3d, 0x0f, 0xbe, 0x7a, 0x1e, 0x14,
+	0xd8, 0x7f, 0xf7, 0xf6, 0x67, 0x00, 0x51, 0x78, 0xae, 0x7e, 0x44, 0x01, 0x00, 0x00,
+}
+
+func (m *OrderCompletedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OrderCompletedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OrderCompletedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Currency) > 0 {
+		i -= len(m.Currency)
+		copy(dAtA[i:], m.Currency)
+		i = encodeVarintOrderCompletedEvent(dAtA, i, uint64(len(m.Currency)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Amount != 0 {
+		i = encodeVarintOrderCompletedEvent(dAtA, i, uint64(m.Amount))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.TicketID != 0 {
+		i = encodeVarintOrderCompletedEvent(dAtA, i, uint64(m.TicketID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.UserID != 0 {
+		i = encodeVarintOrderCompletedEvent(dAtA, i, uint64(m.UserID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Version != 0 {
+		i = encodeVarintOrderCompletedEvent(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ID != 0 {
+		i = encodeVarintOrderCompletedEvent(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintOrderCompletedEvent(dAtA []byte, offset int, v uint64) int {
+	offset -= sovOrderCompletedEvent(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *OrderCompletedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovOrderCompletedEvent(uint64(m.ID))
+	}
+	if m.Version != 0 {
+		n += 1 + sovOrderCompletedEvent(uint64(m.Version))
+	}
+	if m.UserID != 0 {
+		n += 1 + sovOrderCompletedEvent(uint64(m.UserID))
+	}
+	if m.TicketID != 0 {
+		n += 1 + sovOrderCompletedEvent(uint64(m.TicketID))
+	}
+	if m.Amount != 0 {
+		n += 1 + sovOrderCompletedEvent(uint64(m.Amount))
+	}
+	l = len(m.Currency)
+	if l > 0 {
+		n += 1 + l + sovOrderCompletedEvent(uint64(l))
+	}
+	return n
+}
+
+func sovOrderCompletedEvent(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozOrderCompletedEvent(x uint64) (n int) {
+	return sovOrderCompletedEvent(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *OrderCompletedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOrderCompletedEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OrderCompletedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OrderCompletedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderCompletedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderCompletedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserID", wireType)
+			}
+			m.UserID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderCompletedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TicketID", wireType)
+			}
+			m.TicketID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderCompletedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TicketID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			m.Amount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderCompletedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Amount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Currency", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderCompletedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderCompletedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderCompletedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Currency = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOrderCompletedEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOrderCompletedEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipOrderCompletedEvent(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowOrderCompletedEvent
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOrderCompletedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOrderCompletedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthOrderCompletedEvent
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupOrderCompletedEvent
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthOrderCompletedEvent
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthOrderCompletedEvent        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowOrderCompletedEvent          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupOrderCompletedEvent = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,626 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: order_payment_mismatch_event.proto
+
+package schema
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// OrderPaymentMismatchEvent flags a payment received for an order that is
+// unknown or could not be completed, e.g. one cancelled by expiry, so the
+// payment service can refund it. The order fields are empty for an unknown
+// order. order_total is what the order expected, in minor units of currency,
+// not necessarily the amount paid.
+type OrderPaymentMismatchEvent struct {
+	OrderID     int64  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	OrderStatus string `protobuf:"bytes,2,opt,name=order_status,json=orderStatus,proto3" json:"order_status,omitempty"`
+	UserID      int64  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaymentID   int64  `protobuf:"varint,4,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	StripeID    string `protobuf:"bytes,5,opt,name=stripe_id,json=stripeId,proto3" json:"stripe_id,omitempty"`
+	OrderTotal  int64  `protobuf:"varint,6,opt,name=order_total,json=orderTotal,proto3" json:"order_total,omitempty"`
+	Currency    string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Reason      string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *OrderPaymentMismatchEvent) Reset()         { *m = OrderPaymentMismatchEvent{} }
+func (m *OrderPaymentMismatchEvent) String() string { return proto.CompactTextString(m) }
+func (*OrderPaymentMismatchEvent) ProtoMessage()    {}
+func (*OrderPaymentMismatchEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d10168b73b2f76fd, []int{0}
+}
+func (m *OrderPaymentMismatchEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OrderPaymentMismatchEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OrderPaymentMismatchEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OrderPaymentMismatchEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderPaymentMismatchEvent.Merge(m, src)
+}
+func (m *OrderPaymentMismatchEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *OrderPaymentMismatchEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderPaymentMismatchEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderPaymentMismatchEvent proto.InternalMessageInfo
+
+func (m *OrderPaymentMismatchEvent) GetOrderID() int64 {
+	if m != nil {
+		return m.OrderID
+	}
+	return 0
+}
+
+func (m *OrderPaymentMismatchEvent) GetOrderStatus() string {
+	if m != nil {
+		return m.OrderStatus
+	}
+	return ""
+}
+
+func (m *OrderPaymentMismatchEvent) GetUserID() int64 {
+	if m != nil {
+		return m.UserID
+	}
+	return 0
+}
+
+func (m *OrderPaymentMismatchEvent) GetPaymentID() int64 {
+	if m != nil {
+		return m.PaymentID
+	}
+	return 0
+}
+
+func (m *OrderPaymentMismatchEvent) GetStripeID() string {
+	if m != nil {
+		return m.StripeID
+	}
+	return ""
+}
+
+func (m *OrderPaymentMismatchEvent) GetOrderTotal() int64 {
+	if m != nil {
+		return m.OrderTotal
+	}
+	return 0
+}
+
+func (m *OrderPaymentMismatchEvent) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *OrderPaymentMismatchEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*OrderPaymentMismatchEvent)(nil), "schema.OrderPaymentMismatchEvent")
+}
+
+func init() {
+	proto.RegisterFile("order_payment_mismatch_event.proto", fileDescriptor_d10168b73b2f76fd)
+}
+
+var fileDescriptor_d10168b73b2f76fd = []byte{
+	// 327 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x3c, 0x90, 0xbd, 0x4e, 0xeb, 0x30,
+	0x14, 0xc7, 0x9b, 0xf6, 0xde, 0x7c, 0xb8, 0xbd, 0x8b, 0x87, 0x2b, 0xdf, 0x0e, 0x49, 0x6f, 0x91,
+	0x50, 0x91, 0xa0, 0x1d, 0x78, 0x83, 0xaa, 0x0c, 0x1e, 0x10, 0x28, 0x85, 0x39, 0x4a, 0x63, 0xd3,
+	0x46, 0x22, 0x71, 0x65, 0x3b, 0x48, 0x7d, 0x0b, 0x5e, 0x87, 0x37, 0x60, 0xec, 0xc8, 0x14, 0x21,
+	0xf7, 0x45, 0x90, 0x8f, 0x03, 0x5b, 0xfe, 0x1f, 0xe7, 0x77, 0x4e, 0x8c, 0xa6, 0x42, 0x32, 0x2e,
+	0xb3, 0x7d, 0x7e, 0xa8, 0x78, 0xad, 0xb3, 0xaa, 0x54, 0x55, 0xae, 0x8b, 0x5d, 0xc6, 0x5f, 0x78,
+	0xad, 0xe7, 0x7b, 0x29, 0xb4, 0xc0, 0xbe, 0x2a, 0x76, 0xbc, 0xca, 0xc7, 0x57, 0xdb, 0x52, 0xef,
+	0x9a, 0xcd, 0xbc, 0x10, 0xd5, 0x62, 0x2b, 0xb6, 0x62, 0x01, 0xf1, 0xa6, 0x79, 0x02, 0x05, 0x02,
+	0xbe, 0xdc, 0xd8, 0xf4, 0xad, 0x8f, 0xfe, 0xdd, 0x59, 0xfa, 0xbd, 0x83, 0xdf, 0x76, 0xec, 0x1b,
+	0x8b, 0xc6, 0xe7, 0x28, 0x74, 0xab, 0x4b, 0x46, 0xbc, 0x89, 0x37, 0x1b, 0x2c, 0x87, 0xa6, 0x4d,
+	0x02, 0x18, 0xa0, 0xab, 0x34, 0x80, 0x90, 0x32, 0xfc, 0x1f, 0x8d, 0x5c, 0x4f, 0xe9, 0x5c, 0x37,
+	0x8a, 0xf4, 0x27, 0xde, 0x2c, 0x4a, 0x87, 0xe0, 0xad, 0xc1, 0xc2, 0x67, 0x28, 0x68, 0x94, 0x23,
+	0x0d, 0x80, 0x84, 0x4c, 0x9b, 0xf8, 0x8f, 0x0a, 0x40, 0xbe, 0x8d, 0x28, 0xc3, 0x97, 0x08, 0x7d,
+	0xff, 0x64, 0xc9, 0xc8, 0x2f, 0xe8, 0xfd, 0x31, 0x6d, 0x12, 0x75, 0xd7, 0xd1, 0x55, 0x1a, 0x75,
+	0x05, 0xca, 0xf0, 0x05, 0x8a, 0x94, 0x96, 0xe5, 0x9e, 0xdb, 0xf2, 0x6f, 0xbb, 0x72, 0x39, 0x32,
+	0x6d, 0x12, 0xae, 0xc1, 0xa4, 0xab, 0x34, 0x74, 0x31, 0x65, 0x38, 0x41, 0xee, 0x98, 0x4c, 0x0b,
+	0x9d, 0x3f, 0x13, 0xdf, 0x92, 0x53, 0x04, 0xd6, 0x83, 0x75, 0xf0, 0x18, 0x85, 0x45, 0x23, 0x25,
+	0xaf, 0x8b, 0x03, 0x09, 0xe0, 0xfa, 0x1f, 0x8d, 0xff, 0x22, 0x5f, 0xf2, 0x5c, 0x89, 0x9a, 0x84,
+	0x90, 0x74, 0x6a, 0x49, 0xde, 0x4d, 0xec, 0x1d, 0x4d, 0xec, 0x7d, 0x9a, 0xd8, 0x7b, 0x3d, 0xc5,
+	0xbd, 0xe3, 0x29, 0xee, 0x7d, 0x9c, 0xe2, 0xde, 0xc6, 0x87, 0xc7, 0xbd, 0xfe, 0x1a, 0x00, 0xb4,
+	0x87, 0x6d, 0x7b, 0xb9, 0x01, 0x00, 0x00,
+}
+
+func (m *OrderPaymentMismatchEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OrderPaymentMismatchEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OrderPaymentMismatchEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintOrderPaymentMismatchEvent(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.Currency) > 0 {
+		i -= len(m.Currency)
+		copy(dAtA[i:], m.Currency)
+		i = encodeVarintOrderPaymentMismatchEvent(dAtA, i, uint64(len(m.Currency)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.OrderTotal != 0 {
+		i = encodeVarintOrderPaymentMismatchEvent(dAtA, i, uint64(m.OrderTotal))
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.StripeID) > 0 {
+		i -= len(m.StripeID)
+		copy(dAtA[i:], m.StripeID)
+		i = encodeVarintOrderPaymentMismatchEvent(dAtA, i, uint64(len(m.StripeID)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.PaymentID != 0 {
+		i = encodeVarintOrderPaymentMismatchEvent(dAtA, i, uint64(m.PaymentID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.UserID != 0 {
+		i = encodeVarintOrderPaymentMismatchEvent(dAtA, i, uint64(m.UserID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.OrderStatus) > 0 {
+		i -= len(m.OrderStatus)
+		copy(dAtA[i:], m.OrderStatus)
+		i = encodeVarintOrderPaymentMismatchEvent(dAtA, i, uint64(len(m.OrderStatus)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.OrderID != 0 {
+		i = encodeVarintOrderPaymentMismatchEvent(dAtA, i, uint64(m.OrderID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintOrderPaymentMismatchEvent(dAtA []byte, offset int, v uint64) int {
+	offset -= sovOrderPaymentMismatchEvent(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *OrderPaymentMismatchEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderID != 0 {
+		n += 1 + sovOrderPaymentMismatchEvent(uint64(m.OrderID))
+	}
+	l = len(m.OrderStatus)
+	if l > 0 {
+		n += 1 + l + sovOrderPaymentMismatchEvent(uint64(l))
+	}
+	if m.UserID != 0 {
+		n += 1 + sovOrderPaymentMismatchEvent(uint64(m.UserID))
+	}
+	if m.PaymentID != 0 {
+		n += 1 + sovOrderPaymentMismatchEvent(uint64(m.PaymentID))
+	}
+	l = len(m.StripeID)
+	if l > 0 {
+		n += 1 + l + sovOrderPaymentMismatchEvent(uint64(l))
+	}
+	if m.OrderTotal != 0 {
+		n += 1 + sovOrderPaymentMismatchEvent(uint64(m.OrderTotal))
+	}
+	l = len(m.Currency)
+	if l > 0 {
+		n += 1 + l + sovOrderPaymentMismatchEvent(uint64(l))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovOrderPaymentMismatchEvent(uint64(l))
+	}
+	return n
+}
+
+func sovOrderPaymentMismatchEvent(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozOrderPaymentMismatchEvent(x uint64) (n int) {
+	return sovOrderPaymentMismatchEvent(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *OrderPaymentMismatchEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOrderPaymentMismatchEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OrderPaymentMismatchEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OrderPaymentMismatchEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
+			}
+			m.OrderID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderStatus", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderStatus = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserID", wireType)
+			}
+			m.UserID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PaymentID", wireType)
+			}
+			m.PaymentID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PaymentID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StripeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StripeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderTotal", wireType)
+			}
+			m.OrderTotal = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderTotal |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Currency", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Currency = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOrderPaymentMismatchEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipOrderPaymentMismatchEvent(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowOrderPaymentMismatchEvent
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOrderPaymentMismatchEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthOrderPaymentMismatchEvent
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupOrderPaymentMismatchEvent
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthOrderPaymentMismatchEvent
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthOrderPaymentMismatchEvent        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowOrderPaymentMismatchEvent          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupOrderPaymentMismatchEvent = fmt.Errorf("proto: unexpected end of group")
+)
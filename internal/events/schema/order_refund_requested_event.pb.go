@@ -0,0 +1,517 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: order_refund_requested_event.proto
+
+package schema
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// OrderRefundRequestedEvent asks the payment service to refund a completed
+// order. Amount is in minor units of currency.
+type OrderRefundRequestedEvent struct {
+	ID         int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version    int64  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	UserID     int64  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount     int64  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency   string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	ReasonCode string `protobuf:"bytes,6,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+}
+
+func (m *OrderRefundRequestedEvent) Reset()         { *m = OrderRefundRequestedEvent{} }
+func (m *OrderRefundRequestedEvent) String() string { return proto.CompactTextString(m) }
+func (*OrderRefundRequestedEvent) ProtoMessage()    {}
+func (*OrderRefundRequestedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7a4f5d7d5e9d59fe, []int{0}
+}
+func (m *OrderRefundRequestedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OrderRefundRequestedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OrderRefundRequestedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OrderRefundRequestedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderRefundRequestedEvent.Merge(m, src)
+}
+func (m *OrderRefundRequestedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *OrderRefundRequestedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderRefundRequestedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderRefundRequestedEvent proto.InternalMessageInfo
+
+func (m *OrderRefundRequestedEvent) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *OrderRefundRequestedEvent) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *OrderRefundRequestedEvent) GetUserID() int64 {
+	if m != nil {
+		return m.UserID
+	}
+	return 0
+}
+
+func (m *OrderRefundRequestedEvent) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *OrderRefundRequestedEvent) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *OrderRefundRequestedEvent) GetReasonCode() string {
+	if m != nil {
+		return m.ReasonCode
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*OrderRefundRequestedEvent)(nil), "schema.OrderRefundRequestedEvent")
+}
+
+func init() {
+	proto.RegisterFile("order_refund_requested_event.proto", fileDescriptor_7a4f5d7d5e9d59fe)
+}
+
+var fileDescriptor_7a4f5d7d5e9d59fe = []byte{
+	// 266 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x3c, 0x8f, 0x31, 0x4e, 0xc3, 0x30,
+	0x14, 0x86, 0xe3, 0x14, 0x5c, 0x30, 0x9b, 0x87, 0xca, 0x74, 0x70, 0xaa, 0xb2, 0x74, 0xa1, 0x1d,
+	0xb8, 0x41, 0x29, 0x43, 0x26, 0x24, 0x4b, 0xcc, 0x51, 0x12, 0xbf, 0xa6, 0x19, 0x62, 0xc3, 0x73,
+	0x5c, 0x89, 0x5b, 0x70, 0x2b, 0x18, 0x3b, 0x32, 0x55, 0x28, 0xb9, 0x08, 0x8a, 0x4b, 0xd9, 0xfc,
+	0xf9, 0xd3, 0xff, 0x49, 0x8f, 0xcd, 0x2d, 0x6a, 0xc0, 0x0c, 0x61, 0xeb, 0x8d, 0xce, 0x10, 0xde,
+	0x3c, 0xb8, 0x16, 0x74, 0x06, 0x7b, 0x30, 0xed, 0xf2, 0x15, 0x6d, 0x6b, 0x39, 0x75, 0xe5, 0x0e,
+	0x9a, 0x7c, 0x7a, 0x5f, 0xd5, 0xed, 0xce, 0x17, 0xcb, 0xd2, 0x36, 0xab, 0xca, 0x56, 0x76, 0x15,
+	0x74, 0xe1, 0xb7, 0x81, 0x02, 0x84, 0xd7, 0x69, 0x36, 0xff, 0x24, 0xec, 0xf6, 0x79, 0xa8, 0xab,
+	0x10, 0x57, 0xe7, 0xf6, 0xd3, 0x90, 0xe6, 0x13, 0x16, 0xd7, 0x5a, 0x90, 0x19, 0x59, 0x8c, 0xd6,
+	0xb4, 0x3b, 0x26, 0x71, 0xba, 0x51, 0x71, 0xad, 0xb9, 0x60, 0xe3, 0x3d, 0xa0, 0xab, 0xad, 0x11,
+	0xf1, 0x20, 0xd5, 0x19, 0xf9, 0x1d, 0x1b, 0x7b, 0x07, 0x98, 0xd5, 0x5a, 0x8c, 0xc2, 0x8c, 0x75,
+	0xc7, 0x84, 0xbe, 0x38, 0xc0, 0x74, 0xa3, 0xe8, 0xa0, 0x52, 0xcd, 0x27, 0x8c, 0xe6, 0x8d, 0xf5,
+	0xa6, 0x15, 0x17, 0x61, 0xfd, 0x47, 0x7c, 0xca, 0xae, 0x4a, 0x8f, 0x08, 0xa6, 0x7c, 0x17, 0x97,
+	0x33, 0xb2, 0xb8, 0x56, 0xff, 0xcc, 0x13, 0x76, 0x83, 0x90, 0x3b, 0x6b, 0xb2, 0xd2, 0x6a, 0x10,
+	0x34, 0x68, 0x76, 0xfa, 0x7a, 0xb4, 0x1a, 0xd6, 0xe2, 0xab, 0x93, 0xe4, 0xd0, 0x49, 0xf2, 0xd3,
+	0x49, 0xf2, 0xd1, 0xcb, 0xe8, 0xd0, 0xcb, 0xe8, 0xbb, 0x97, 0x51, 0x41, 0xc3, 0xa9, 0x0f, 0xbf,
+	0x03, 0x00, 0xbd, 0x64, 0x6d, 0x5f, 0x47, 0x01, 0x00, 0x00,
+}
+
+func (m *OrderRefundRequestedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OrderRefundRequestedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OrderRefundRequestedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ReasonCode) > 0 {
+		i -= len(m.ReasonCode)
+		copy(dAtA[i:], m.ReasonCode)
+		i = encodeVarintOrderRefundRequestedEvent(dAtA, i, uint64(len(m.ReasonCode)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Currency) > 0 {
+		i -= len(m.Currency)
+		copy(dAtA[i:], m.Currency)
+		i = encodeVarintOrderRefundRequestedEvent(dAtA, i, uint64(len(m.Currency)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Amount != 0 {
+		i = encodeVarintOrderRefundRequestedEvent(dAtA, i, uint64(m.Amount))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.UserID != 0 {
+		i = encodeVarintOrderRefundRequestedEvent(dAtA, i, uint64(m.UserID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Version != 0 {
+		i = encodeVarintOrderRefundRequestedEvent(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ID != 0 {
+		i = encodeVarintOrderRefundRequestedEvent(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintOrderRefundRequestedEvent(dAtA []byte, offset int, v uint64) int {
+	offset -= sovOrderRefundRequestedEvent(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *OrderRefundRequestedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovOrderRefundRequestedEvent(uint64(m.ID))
+	}
+	if m.Version != 0 {
+		n += 1 + sovOrderRefundRequestedEvent(uint64(m.Version))
+	}
+	if m.UserID != 0 {
+		n += 1 + sovOrderRefundRequestedEvent(uint64(m.UserID))
+	}
+	if m.Amount != 0 {
+		n += 1 + sovOrderRefundRequestedEvent(uint64(m.Amount))
+	}
+	l = len(m.Currency)
+	if l > 0 {
+		n += 1 + l + sovOrderRefundRequestedEvent(uint64(l))
+	}
+	l = len(m.ReasonCode)
+	if l > 0 {
+		n += 1 + l + sovOrderRefundRequestedEvent(uint64(l))
+	}
+	return n
+}
+
+func sovOrderRefundRequestedEvent(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozOrderRefundRequestedEvent(x uint64) (n int) {
+	return sovOrderRefundRequestedEvent(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *OrderRefundRequestedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOrderRefundRequestedEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OrderRefundRequestedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OrderRefundRequestedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderRefundRequestedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderRefundRequestedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserID", wireType)
+			}
+			m.UserID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderRefundRequestedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			m.Amount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderRefundRequestedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Amount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Currency", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderRefundRequestedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderRefundRequestedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderRefundRequestedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Currency = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReasonCode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderRefundRequestedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderRefundRequestedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderRefundRequestedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReasonCode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOrderRefundRequestedEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOrderRefundRequestedEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipOrderRefundRequestedEvent(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowOrderRefundRequestedEvent
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOrderRefundRequestedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOrderRefundRequestedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthOrderRefundRequestedEvent
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupOrderRefundRequestedEvent
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthOrderRefundRequestedEvent
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthOrderRefundRequestedEvent        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowOrderRefundRequestedEvent          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupOrderRefundRequestedEvent = fmt.Errorf("proto: unexpected end of group")
+)
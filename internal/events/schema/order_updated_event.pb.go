@@ -0,0 +1,1496 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: order_updated_event.proto
+
+package schema
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// OrderUpdatedEvent carries the full state of an order after each change of
+// its status, including its creation, when previous_status is empty.
+// Consumers keeping a copy of the order can drop events older than the
+// version they hold. total and the unit prices of the items are in minor
+// units of currency.
+type OrderUpdatedEvent struct {
+	ID                 int64                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status             string                  `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	PreviousStatus     string                  `protobuf:"bytes,3,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	Version            int64                   `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	UserID             int64                   `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpiresAt          string                  `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt          string                  `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Total              int64                   `protobuf:"varint,8,opt,name=total,proto3" json:"total,omitempty"`
+	Currency           string                  `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	Items              []*OrderUpdatedItem     `protobuf:"bytes,10,rep,name=items,proto3" json:"items,omitempty"`
+	CancellationReason string                  `protobuf:"bytes,11,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
+	CancellationNote   string                  `protobuf:"bytes,12,opt,name=cancellation_note,json=cancellationNote,proto3" json:"cancellation_note,omitempty"`
+	Change             *OrderUpdatedStatusInfo `protobuf:"bytes,13,opt,name=change,proto3" json:"change,omitempty"`
+}
+
+func (m *OrderUpdatedEvent) Reset()         { *m = OrderUpdatedEvent{} }
+func (m *OrderUpdatedEvent) String() string { return proto.CompactTextString(m) }
+func (*OrderUpdatedEvent) ProtoMessage()    {}
+func (*OrderUpdatedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b7306994c9acdca0, []int{0}
+}
+func (m *OrderUpdatedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OrderUpdatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OrderUpdatedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OrderUpdatedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderUpdatedEvent.Merge(m, src)
+}
+func (m *OrderUpdatedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *OrderUpdatedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderUpdatedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderUpdatedEvent proto.InternalMessageInfo
+
+func (m *OrderUpdatedEvent) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *OrderUpdatedEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *OrderUpdatedEvent) GetPreviousStatus() string {
+	if m != nil {
+		return m.PreviousStatus
+	}
+	return ""
+}
+
+func (m *OrderUpdatedEvent) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *OrderUpdatedEvent) GetUserID() int64 {
+	if m != nil {
+		return m.UserID
+	}
+	return 0
+}
+
+func (m *OrderUpdatedEvent) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *OrderUpdatedEvent) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *OrderUpdatedEvent) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *OrderUpdatedEvent) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *OrderUpdatedEvent) GetItems() []*OrderUpdatedItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *OrderUpdatedEvent) GetCancellationReason() string {
+	if m != nil {
+		return m.CancellationReason
+	}
+	return ""
+}
+
+func (m *OrderUpdatedEvent) GetCancellationNote() string {
+	if m != nil {
+		return m.CancellationNote
+	}
+	return ""
+}
+
+func (m *OrderUpdatedEvent) GetChange() *OrderUpdatedStatusInfo {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+type OrderUpdatedItem struct {
+	TicketID  int64  `protobuf:"varint,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	Title     string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Quantity  int64  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice int64  `protobuf:"varint,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+}
+
+func (m *OrderUpdatedItem) Reset()         { *m = OrderUpdatedItem{} }
+func (m *OrderUpdatedItem) String() string { return proto.CompactTextString(m) }
+func (*OrderUpdatedItem) ProtoMessage()    {}
+func (*OrderUpdatedItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b7306994c9acdca0, []int{1}
+}
+func (m *OrderUpdatedItem) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OrderUpdatedItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OrderUpdatedItem.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OrderUpdatedItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderUpdatedItem.Merge(m, src)
+}
+func (m *OrderUpdatedItem) XXX_Size() int {
+	return m.Size()
+}
+func (m *OrderUpdatedItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderUpdatedItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderUpdatedItem proto.InternalMessageInfo
+
+func (m *OrderUpdatedItem) GetTicketID() int64 {
+	if m != nil {
+		return m.TicketID
+	}
+	return 0
+}
+
+func (m *OrderUpdatedItem) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *OrderUpdatedItem) GetQuantity() int64 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *OrderUpdatedItem) GetUnitPrice() int64 {
+	if m != nil {
+		return m.UnitPrice
+	}
+	return 0
+}
+
+// OrderUpdatedStatusInfo describes who changed the order status and why, as
+// recorded in the order history.
+type OrderUpdatedStatusInfo struct {
+	ActorType string `protobuf:"bytes,1,opt,name=actor_type,json=actorType,proto3" json:"actor_type,omitempty"`
+	ActorID   int64  `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Reason    string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedAt string `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+}
+
+func (m *OrderUpdatedStatusInfo) Reset()         { *m = OrderUpdatedStatusInfo{} }
+func (m *OrderUpdatedStatusInfo) String() string { return proto.CompactTextString(m) }
+func (*OrderUpdatedStatusInfo) ProtoMessage()    {}
+func (*OrderUpdatedStatusInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b7306994c9acdca0, []int{2}
+}
+func (m *OrderUpdatedStatusInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OrderUpdatedStatusInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OrderUpdatedStatusInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *OrderUpdatedStatusInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderUpdatedStatusInfo.Merge(m, src)
+}
+func (m *OrderUpdatedStatusInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *OrderUpdatedStatusInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderUpdatedStatusInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderUpdatedStatusInfo proto.InternalMessageInfo
+
+func (m *OrderUpdatedStatusInfo) GetActorType() string {
+	if m != nil {
+		return m.ActorType
+	}
+	return ""
+}
+
+func (m *OrderUpdatedStatusInfo) GetActorID() int64 {
+	if m != nil {
+		return m.ActorID
+	}
+	return 0
+}
+
+func (m *OrderUpdatedStatusInfo) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *OrderUpdatedStatusInfo) GetChangedAt() string {
+	if m != nil {
+		return m.ChangedAt
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*OrderUpdatedEvent)(nil), "schema.OrderUpdatedEvent")
+	proto.RegisterType((*OrderUpdatedItem)(nil), "schema.OrderUpdatedItem")
+	proto.RegisterType((*OrderUpdatedStatusInfo)(nil), "schema.OrderUpdatedStatusInfo")
+}
+
+func init() { proto.RegisterFile("order_updated_event.proto", fileDescriptor_b7306994c9acdca0) }
+
+var fileDescriptor_b7306994c9acdca0 = []byte{
+	// 540 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xcf, 0x6e, 0xd3, 0x4c,
+	0x10, 0xaf, 0xe3, 0xd6, 0x89, 0x37, 0xfd, 0x3e, 0xda, 0x05, 0x55, 0x4b, 0x25, 0x9c, 0xa8, 0x48,
+	0x10, 0x84, 0x48, 0xa5, 0x22, 0x71, 0x4f, 0x15, 0x0e, 0xbe, 0x00, 0x32, 0xed, 0xd9, 0xda, 0xd8,
+	0xd3, 0x64, 0x45, 0xe2, 0x35, 0xeb, 0x71, 0x44, 0x5e, 0x02, 0x71, 0xe2, 0x99, 0x38, 0xf6, 0xc8,
+	0x29, 0x02, 0xe7, 0x45, 0x90, 0x67, 0xdd, 0x28, 0xa0, 0xde, 0xfc, 0xfb, 0x33, 0xb3, 0xa3, 0xdf,
+	0x8c, 0xd9, 0x63, 0x6d, 0x52, 0x30, 0x71, 0x99, 0xa7, 0x12, 0x21, 0x8d, 0x61, 0x09, 0x19, 0x0e,
+	0x73, 0xa3, 0x51, 0x73, 0xaf, 0x48, 0x66, 0xb0, 0x90, 0xa7, 0xaf, 0xa6, 0x0a, 0x67, 0xe5, 0x64,
+	0x98, 0xe8, 0xc5, 0xf9, 0x54, 0x4f, 0xf5, 0x39, 0xc9, 0x93, 0xf2, 0x86, 0x10, 0x01, 0xfa, 0xb2,
+	0x65, 0x67, 0xbf, 0x5d, 0x76, 0xfc, 0xbe, 0x6e, 0x7a, 0x6d, 0x7b, 0xbe, 0xad, 0x5b, 0xf2, 0x13,
+	0xd6, 0x52, 0xa9, 0x70, 0xfa, 0xce, 0xc0, 0xbd, 0xf4, 0xaa, 0x75, 0xaf, 0x15, 0x8e, 0xa3, 0x96,
+	0x4a, 0xf9, 0x09, 0xf3, 0x0a, 0x94, 0x58, 0x16, 0xa2, 0xd5, 0x77, 0x06, 0x7e, 0xd4, 0x20, 0xfe,
+	0x9c, 0x3d, 0xc8, 0x0d, 0x2c, 0x95, 0x2e, 0x8b, 0xb8, 0x31, 0xb8, 0x64, 0xf8, 0xff, 0x8e, 0xfe,
+	0x68, 0x8d, 0x82, 0xb5, 0x97, 0x60, 0x0a, 0xa5, 0x33, 0xb1, 0x5f, 0x77, 0x8f, 0xee, 0x20, 0x7f,
+	0xca, 0xda, 0x65, 0x01, 0x26, 0x56, 0xa9, 0x38, 0xa0, 0x77, 0x59, 0xb5, 0xee, 0x79, 0xd7, 0x05,
+	0x98, 0x70, 0x1c, 0x79, 0xb5, 0x14, 0xa6, 0xfc, 0x09, 0x63, 0xf0, 0x25, 0x57, 0x06, 0x8a, 0x58,
+	0xa2, 0xf0, 0xe8, 0x09, 0xbf, 0x61, 0x46, 0x58, 0xcb, 0x89, 0x01, 0x8a, 0x46, 0xa2, 0x68, 0x5b,
+	0xb9, 0x61, 0x46, 0xc8, 0x1f, 0xb1, 0x03, 0xd4, 0x28, 0xe7, 0xa2, 0x43, 0x4f, 0x5b, 0xc0, 0x4f,
+	0x59, 0x27, 0x29, 0x8d, 0x81, 0x2c, 0x59, 0x09, 0x9f, 0x4a, 0xb6, 0x98, 0x0f, 0xd9, 0x81, 0x42,
+	0x58, 0x14, 0x82, 0xf5, 0xdd, 0x41, 0xf7, 0x42, 0x0c, 0x6d, 0xc8, 0xc3, 0xdd, 0xc4, 0x42, 0x84,
+	0x45, 0x64, 0x6d, 0xfc, 0x9c, 0x3d, 0x4c, 0x64, 0x96, 0xc0, 0x7c, 0x2e, 0x51, 0xe9, 0x2c, 0x36,
+	0x20, 0x0b, 0x9d, 0x89, 0x2e, 0xb5, 0xe5, 0xbb, 0x52, 0x44, 0x0a, 0x7f, 0xc9, 0x8e, 0xff, 0x2a,
+	0xc8, 0x34, 0x82, 0x38, 0x24, 0xfb, 0xd1, 0xae, 0xf0, 0x4e, 0x23, 0xf0, 0x37, 0xcc, 0x4b, 0x66,
+	0x32, 0x9b, 0x82, 0xf8, 0xaf, 0xef, 0x0c, 0xba, 0x17, 0xc1, 0x7d, 0xe3, 0xd8, 0xa0, 0xc3, 0xec,
+	0x46, 0x47, 0x8d, 0xfb, 0xec, 0xab, 0xc3, 0x8e, 0xfe, 0x9d, 0x98, 0xbf, 0x60, 0x3e, 0xaa, 0xe4,
+	0x13, 0x60, 0xbc, 0xdd, 0xf4, 0x61, 0xb5, 0xee, 0x75, 0xae, 0x88, 0x0c, 0xc7, 0x51, 0xc7, 0xca,
+	0x61, 0x4a, 0xb9, 0x29, 0x9c, 0x43, 0xb3, 0x74, 0x0b, 0xea, 0xdc, 0x3e, 0x97, 0x32, 0x43, 0x85,
+	0x2b, 0x5a, 0xb6, 0x1b, 0x6d, 0x71, 0xbd, 0x88, 0x32, 0x53, 0x18, 0xe7, 0x46, 0x25, 0xd0, 0x6c,
+	0xda, 0xaf, 0x99, 0x0f, 0x35, 0x71, 0xf6, 0xdd, 0x61, 0x27, 0xf7, 0xcf, 0x5c, 0x57, 0xca, 0x04,
+	0xb5, 0x89, 0x71, 0x95, 0x03, 0xcd, 0xe5, 0x47, 0x3e, 0x31, 0x57, 0xab, 0x1c, 0xf8, 0x33, 0xd6,
+	0xb1, 0xb2, 0x4a, 0x69, 0x1a, 0xf7, 0xb2, 0x5b, 0xad, 0x7b, 0xed, 0x51, 0xcd, 0x85, 0xe3, 0xa8,
+	0x4d, 0x62, 0x48, 0x87, 0xda, 0x64, 0x6f, 0xef, 0xb0, 0x41, 0x74, 0x21, 0x14, 0x0a, 0x5d, 0xc8,
+	0x7e, 0x73, 0x21, 0x96, 0x19, 0xe1, 0xa5, 0xf8, 0x51, 0x05, 0xce, 0x6d, 0x15, 0x38, 0xbf, 0xaa,
+	0xc0, 0xf9, 0xb6, 0x09, 0xf6, 0x6e, 0x37, 0xc1, 0xde, 0xcf, 0x4d, 0xb0, 0x37, 0xf1, 0xe8, 0x77,
+	0x79, 0xfd, 0x67, 0x00, 0xf5, 0x8d, 0x1f, 0x95, 0x82, 0x03, 0x00, 0x00,
+}
+
+func (m *OrderUpdatedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OrderUpdatedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OrderUpdatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Change != nil {
+		{
+			size, err := m.Change.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.CancellationNote) > 0 {
+		i -= len(m.CancellationNote)
+		copy(dAtA[i:], m.CancellationNote)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.CancellationNote)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.CancellationReason) > 0 {
+		i -= len(m.CancellationReason)
+		copy(dAtA[i:], m.CancellationReason)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.CancellationReason)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.Currency) > 0 {
+		i -= len(m.Currency)
+		copy(dAtA[i:], m.Currency)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.Currency)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Total != 0 {
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.CreatedAt) > 0 {
+		i -= len(m.CreatedAt)
+		copy(dAtA[i:], m.CreatedAt)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.CreatedAt)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.ExpiresAt) > 0 {
+		i -= len(m.ExpiresAt)
+		copy(dAtA[i:], m.ExpiresAt)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.ExpiresAt)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.UserID != 0 {
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(m.UserID))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Version != 0 {
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.PreviousStatus) > 0 {
+		i -= len(m.PreviousStatus)
+		copy(dAtA[i:], m.PreviousStatus)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.PreviousStatus)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OrderUpdatedItem) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OrderUpdatedItem) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OrderUpdatedItem) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.UnitPrice != 0 {
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(m.UnitPrice))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Quantity != 0 {
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(m.Quantity))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Title) > 0 {
+		i -= len(m.Title)
+		copy(dAtA[i:], m.Title)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.Title)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TicketID != 0 {
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(m.TicketID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OrderUpdatedStatusInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OrderUpdatedStatusInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OrderUpdatedStatusInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChangedAt) > 0 {
+		i -= len(m.ChangedAt)
+		copy(dAtA[i:], m.ChangedAt)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.ChangedAt)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.ActorID != 0 {
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(m.ActorID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ActorType) > 0 {
+		i -= len(m.ActorType)
+		copy(dAtA[i:], m.ActorType)
+		i = encodeVarintOrderUpdatedEvent(dAtA, i, uint64(len(m.ActorType)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintOrderUpdatedEvent(dAtA []byte, offset int, v uint64) int {
+	offset -= sovOrderUpdatedEvent(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *OrderUpdatedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovOrderUpdatedEvent(uint64(m.ID))
+	}
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	l = len(m.PreviousStatus)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovOrderUpdatedEvent(uint64(m.Version))
+	}
+	if m.UserID != 0 {
+		n += 1 + sovOrderUpdatedEvent(uint64(m.UserID))
+	}
+	l = len(m.ExpiresAt)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	l = len(m.CreatedAt)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	if m.Total != 0 {
+		n += 1 + sovOrderUpdatedEvent(uint64(m.Total))
+	}
+	l = len(m.Currency)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+		}
+	}
+	l = len(m.CancellationReason)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	l = len(m.CancellationNote)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	if m.Change != nil {
+		l = m.Change.Size()
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	return n
+}
+
+func (m *OrderUpdatedItem) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TicketID != 0 {
+		n += 1 + sovOrderUpdatedEvent(uint64(m.TicketID))
+	}
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	if m.Quantity != 0 {
+		n += 1 + sovOrderUpdatedEvent(uint64(m.Quantity))
+	}
+	if m.UnitPrice != 0 {
+		n += 1 + sovOrderUpdatedEvent(uint64(m.UnitPrice))
+	}
+	return n
+}
+
+func (m *OrderUpdatedStatusInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ActorType)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	if m.ActorID != 0 {
+		n += 1 + sovOrderUpdatedEvent(uint64(m.ActorID))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	l = len(m.ChangedAt)
+	if l > 0 {
+		n += 1 + l + sovOrderUpdatedEvent(uint64(l))
+	}
+	return n
+}
+
+func sovOrderUpdatedEvent(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozOrderUpdatedEvent(x uint64) (n int) {
+	return sovOrderUpdatedEvent(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *OrderUpdatedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOrderUpdatedEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OrderUpdatedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OrderUpdatedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousStatus", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousStatus = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserID", wireType)
+			}
+			m.UserID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpiresAt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreatedAt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Currency", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Currency = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &OrderUpdatedItem{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CancellationReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CancellationReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CancellationNote", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CancellationNote = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Change", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Change == nil {
+				m.Change = &OrderUpdatedStatusInfo{}
+			}
+			if err := m.Change.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOrderUpdatedEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OrderUpdatedItem) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOrderUpdatedEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OrderUpdatedItem: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OrderUpdatedItem: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TicketID", wireType)
+			}
+			m.TicketID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TicketID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Title = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quantity", wireType)
+			}
+			m.Quantity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Quantity |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnitPrice", wireType)
+			}
+			m.UnitPrice = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnitPrice |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOrderUpdatedEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OrderUpdatedStatusInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOrderUpdatedEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OrderUpdatedStatusInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OrderUpdatedStatusInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActorType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ActorType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActorID", wireType)
+			}
+			m.ActorID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActorID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangedAt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChangedAt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOrderUpdatedEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOrderUpdatedEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipOrderUpdatedEvent(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowOrderUpdatedEvent
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOrderUpdatedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthOrderUpdatedEvent
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupOrderUpdatedEvent
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthOrderUpdatedEvent
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthOrderUpdatedEvent        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowOrderUpdatedEvent          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupOrderUpdatedEvent = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,355 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: refund_completed_event.proto
+
+package schema
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// RefundCompletedEvent is published by the payment service once the money of
+// an order has been returned.
+type RefundCompletedEvent struct {
+	OrderID  int64  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	RefundID string `protobuf:"bytes,2,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+}
+
+func (m *RefundCompletedEvent) Reset()         { *m = RefundCompletedEvent{} }
+func (m *RefundCompletedEvent) String() string { return proto.CompactTextString(m) }
+func (*RefundCompletedEvent) ProtoMessage()    {}
+func (*RefundCompletedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c18f25b416c2fd5, []int{0}
+}
+func (m *RefundCompletedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RefundCompletedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RefundCompletedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RefundCompletedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundCompletedEvent.Merge(m, src)
+}
+func (m *RefundCompletedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *RefundCompletedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundCompletedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundCompletedEvent proto.InternalMessageInfo
+
+func (m *RefundCompletedEvent) GetOrderID() int64 {
+	if m != nil {
+		return m.OrderID
+	}
+	return 0
+}
+
+func (m *RefundCompletedEvent) GetRefundID() string {
+	if m != nil {
+		return m.RefundID
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*RefundCompletedEvent)(nil), "schema.RefundCompletedEvent")
+}
+
+func init() { proto.RegisterFile("refund_completed_event.proto", fileDescriptor_0c18f25b416c2fd5) }
+
+var fileDescriptor_0c18f25b416c2fd5 = []byte{
+	// 201 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x29, 0x4a, 0x4d, 0x2b,
+	0xcd, 0x4b, 0x89, 0x4f, 0xce, 0xcf, 0x2d, 0xc8, 0x49, 0x2d, 0x49, 0x4d, 0x89, 0x4f, 0x2d, 0x4b,
+	0xcd, 0x2b, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2b, 0x4e, 0xce, 0x48, 0xcd, 0x4d,
+	0x94, 0xd2, 0x4d, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0xcf, 0x4f,
+	0xcf, 0xd7, 0x07, 0x4b, 0x27, 0x95, 0xa6, 0x81, 0x79, 0x60, 0x0e, 0x98, 0x05, 0xd1, 0xa6, 0x94,
+	0xc9, 0x25, 0x12, 0x04, 0x36, 0xd6, 0x19, 0x66, 0xaa, 0x2b, 0xc8, 0x50, 0x21, 0x35, 0x2e, 0x8e,
+	0xfc, 0xa2, 0x94, 0xd4, 0xa2, 0xf8, 0xcc, 0x14, 0x09, 0x46, 0x05, 0x46, 0x0d, 0x66, 0x27, 0xee,
+	0x47, 0xf7, 0xe4, 0xd9, 0xfd, 0x41, 0x62, 0x9e, 0x2e, 0x41, 0xec, 0x60, 0x49, 0xcf, 0x14, 0x21,
+	0x4d, 0x2e, 0x4e, 0xa8, 0xb3, 0x32, 0x53, 0x24, 0x98, 0x14, 0x18, 0x35, 0x38, 0x9d, 0x78, 0x1e,
+	0xdd, 0x93, 0xe7, 0x80, 0x18, 0xea, 0xe9, 0x12, 0xc4, 0x01, 0x91, 0xf6, 0x4c, 0x71, 0x92, 0x38,
+	0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63,
+	0xb8, 0xf0, 0x58, 0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86, 0x24, 0x36, 0xb0, 0x5b, 0x8c, 0x01, 0x03,
+	0x00, 0x43, 0x9d, 0xcc, 0xe3, 0xe2, 0x00, 0x00, 0x00,
+}
+
+func (m *RefundCompletedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RefundCompletedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RefundCompletedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RefundID) > 0 {
+		i -= len(m.RefundID)
+		copy(dAtA[i:], m.RefundID)
+		i = encodeVarintRefundCompletedEvent(dAtA, i, uint64(len(m.RefundID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.OrderID != 0 {
+		i = encodeVarintRefundCompletedEvent(dAtA, i, uint64(m.OrderID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintRefundCompletedEvent(dAtA []byte, offset int, v uint64) int {
+	offset -= sovRefundCompletedEvent(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *RefundCompletedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.OrderID != 0 {
+		n += 1 + sovRefundCompletedEvent(uint64(m.OrderID))
+	}
+	l = len(m.RefundID)
+	if l > 0 {
+		n += 1 + l + sovRefundCompletedEvent(uint64(l))
+	}
+	return n
+}
+
+func sovRefundCompletedEvent(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozRefundCompletedEvent(x uint64) (n int) {
+	return sovRefundCompletedEvent(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RefundCompletedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRefundCompletedEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RefundCompletedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RefundCompletedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
+			}
+			m.OrderID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRefundCompletedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RefundID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRefundCompletedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRefundCompletedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRefundCompletedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RefundID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRefundCompletedEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRefundCompletedEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipRefundCompletedEvent(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowRefundCompletedEvent
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRefundCompletedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRefundCompletedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthRefundCompletedEvent
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupRefundCompletedEvent
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthRefundCompletedEvent
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthRefundCompletedEvent        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowRefundCompletedEvent          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupRefundCompletedEvent = fmt.Errorf("proto: unexpected end of group")
+)
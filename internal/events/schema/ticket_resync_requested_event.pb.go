@@ -0,0 +1,378 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: ticket_resync_requested_event.proto
+
+package schema
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// TicketResyncRequestedEvent asks the tickets service to publish the ticket
+// again. Updates missing_from up to but excluding missing_to never arrived,
+// and the ticket was fast-forwarded to version missing_to.
+type TicketResyncRequestedEvent struct {
+	TicketID    int64 `protobuf:"varint,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	MissingFrom int64 `protobuf:"varint,2,opt,name=missing_from,json=missingFrom,proto3" json:"missing_from,omitempty"`
+	MissingTo   int64 `protobuf:"varint,3,opt,name=missing_to,json=missingTo,proto3" json:"missing_to,omitempty"`
+}
+
+func (m *TicketResyncRequestedEvent) Reset()         { *m = TicketResyncRequestedEvent{} }
+func (m *TicketResyncRequestedEvent) String() string { return proto.CompactTextString(m) }
+func (*TicketResyncRequestedEvent) ProtoMessage()    {}
+func (*TicketResyncRequestedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f6b4f70d5a8341fe, []int{0}
+}
+func (m *TicketResyncRequestedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TicketResyncRequestedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TicketResyncRequestedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TicketResyncRequestedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TicketResyncRequestedEvent.Merge(m, src)
+}
+func (m *TicketResyncRequestedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *TicketResyncRequestedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_TicketResyncRequestedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TicketResyncRequestedEvent proto.InternalMessageInfo
+
+func (m *TicketResyncRequestedEvent) GetTicketID() int64 {
+	if m != nil {
+		return m.TicketID
+	}
+	return 0
+}
+
+func (m *TicketResyncRequestedEvent) GetMissingFrom() int64 {
+	if m != nil {
+		return m.MissingFrom
+	}
+	return 0
+}
+
+func (m *TicketResyncRequestedEvent) GetMissingTo() int64 {
+	if m != nil {
+		return m.MissingTo
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*TicketResyncRequestedEvent)(nil), "schema.TicketResyncRequestedEvent")
+}
+
+func init() {
+	proto.RegisterFile("ticket_resync_requested_event.proto", fileDescriptor_f6b4f70d5a8341fe)
+}
+
+var fileDescriptor_f6b4f70d5a8341fe = []byte{
+	// 221 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x2e, 0xc9, 0x4c, 0xce,
+	0x4e, 0x2d, 0x89, 0x2f, 0x4a, 0x2d, 0xae, 0xcc, 0x4b, 0x8e, 0x2f, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d,
+	0x2e, 0x49, 0x4d, 0x89, 0x4f, 0x2d, 0x4b, 0xcd, 0x2b, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
+	0x62, 0x2b, 0x4e, 0xce, 0x48, 0xcd, 0x4d, 0x94, 0xd2, 0x4d, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2,
+	0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0xcf, 0x4f, 0xcf, 0xd7, 0x07, 0x4b, 0x27, 0x95, 0xa6, 0x81, 0x79,
+	0x60, 0x0e, 0x98, 0x05, 0xd1, 0xa6, 0xd4, 0xc9, 0xc8, 0x25, 0x15, 0x02, 0x36, 0x3e, 0x08, 0x6c,
+	0x7a, 0x10, 0xcc, 0x70, 0x57, 0x90, 0xd9, 0x42, 0x9a, 0x5c, 0x9c, 0x50, 0xcb, 0x33, 0x53, 0x24,
+	0x18, 0x15, 0x18, 0x35, 0x98, 0x9d, 0x78, 0x1e, 0xdd, 0x93, 0xe7, 0x80, 0x68, 0xf1, 0x74, 0x09,
+	0xe2, 0x80, 0x48, 0x7b, 0xa6, 0x08, 0x29, 0x72, 0xf1, 0xe4, 0x66, 0x16, 0x17, 0x67, 0xe6, 0xa5,
+	0xc7, 0xa7, 0x15, 0xe5, 0xe7, 0x4a, 0x30, 0x81, 0x54, 0x07, 0x71, 0x43, 0xc5, 0xdc, 0x8a, 0xf2,
+	0x73, 0x85, 0x64, 0xb9, 0xb8, 0x60, 0x4a, 0x4a, 0xf2, 0x25, 0x98, 0xc1, 0x0a, 0x38, 0xa1, 0x22,
+	0x21, 0xf9, 0x4e, 0x12, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c,
+	0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x90, 0xc4, 0x06,
+	0x76, 0xac, 0x31, 0x60, 0x00, 0x00, 0x18, 0x90, 0xfe, 0x0a, 0x01, 0x00, 0x00,
+}
+
+func (m *TicketResyncRequestedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TicketResyncRequestedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TicketResyncRequestedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MissingTo != 0 {
+		i = encodeVarintTicketResyncRequestedEvent(dAtA, i, uint64(m.MissingTo))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MissingFrom != 0 {
+		i = encodeVarintTicketResyncRequestedEvent(dAtA, i, uint64(m.MissingFrom))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.TicketID != 0 {
+		i = encodeVarintTicketResyncRequestedEvent(dAtA, i, uint64(m.TicketID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTicketResyncRequestedEvent(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTicketResyncRequestedEvent(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *TicketResyncRequestedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TicketID != 0 {
+		n += 1 + sovTicketResyncRequestedEvent(uint64(m.TicketID))
+	}
+	if m.MissingFrom != 0 {
+		n += 1 + sovTicketResyncRequestedEvent(uint64(m.MissingFrom))
+	}
+	if m.MissingTo != 0 {
+		n += 1 + sovTicketResyncRequestedEvent(uint64(m.MissingTo))
+	}
+	return n
+}
+
+func sovTicketResyncRequestedEvent(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTicketResyncRequestedEvent(x uint64) (n int) {
+	return sovTicketResyncRequestedEvent(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *TicketResyncRequestedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTicketResyncRequestedEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TicketResyncRequestedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TicketResyncRequestedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TicketID", wireType)
+			}
+			m.TicketID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTicketResyncRequestedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TicketID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MissingFrom", wireType)
+			}
+			m.MissingFrom = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTicketResyncRequestedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MissingFrom |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MissingTo", wireType)
+			}
+			m.MissingTo = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTicketResyncRequestedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MissingTo |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTicketResyncRequestedEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTicketResyncRequestedEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTicketResyncRequestedEvent(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTicketResyncRequestedEvent
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTicketResyncRequestedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTicketResyncRequestedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTicketResyncRequestedEvent
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupTicketResyncRequestedEvent
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthTicketResyncRequestedEvent
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthTicketResyncRequestedEvent        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTicketResyncRequestedEvent          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupTicketResyncRequestedEvent = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,14 @@
+// Package schema holds the events this service exchanges that are not yet
+// part of ticketing-common. They are protobuf messages generated from the
+// .proto files next to them, with the same conventions as the shared types.
+package schema
+
+const (
+	OrderCompleted        = "order-completed"
+	OrderUpdated          = "order-updated"
+	OrderRefundRequested  = "order-refund-requested"
+	RefundCompleted       = "refund-completed"
+	OrderPaymentMismatch  = "order-payment-mismatch"
+	WaitlistPromoted      = "waitlist-promoted"
+	TicketResyncRequested = "ticket-resync-requested"
+)
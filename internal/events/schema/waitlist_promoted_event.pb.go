@@ -0,0 +1,465 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: waitlist_promoted_event.proto
+
+package schema
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// WaitlistPromotedEvent tells that a waitlisted user got an order for the
+// ticket they were waiting for, to be paid before expires_at.
+type WaitlistPromotedEvent struct {
+	EntryID   int64  `protobuf:"varint,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	TicketID  int64  `protobuf:"varint,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	UserID    int64  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderID   int64  `protobuf:"varint,4,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ExpiresAt string `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (m *WaitlistPromotedEvent) Reset()         { *m = WaitlistPromotedEvent{} }
+func (m *WaitlistPromotedEvent) String() string { return proto.CompactTextString(m) }
+func (*WaitlistPromotedEvent) ProtoMessage()    {}
+func (*WaitlistPromotedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ccf713e606c2f70c, []int{0}
+}
+func (m *WaitlistPromotedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WaitlistPromotedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WaitlistPromotedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WaitlistPromotedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WaitlistPromotedEvent.Merge(m, src)
+}
+func (m *WaitlistPromotedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *WaitlistPromotedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_WaitlistPromotedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WaitlistPromotedEvent proto.InternalMessageInfo
+
+func (m *WaitlistPromotedEvent) GetEntryID() int64 {
+	if m != nil {
+		return m.EntryID
+	}
+	return 0
+}
+
+func (m *WaitlistPromotedEvent) GetTicketID() int64 {
+	if m != nil {
+		return m.TicketID
+	}
+	return 0
+}
+
+func (m *WaitlistPromotedEvent) GetUserID() int64 {
+	if m != nil {
+		return m.UserID
+	}
+	return 0
+}
+
+func (m *WaitlistPromotedEvent) GetOrderID() int64 {
+	if m != nil {
+		return m.OrderID
+	}
+	return 0
+}
+
+func (m *WaitlistPromotedEvent) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*WaitlistPromotedEvent)(nil), "schema.WaitlistPromotedEvent")
+}
+
+func init() { proto.RegisterFile("waitlist_promoted_event.proto", fileDescriptor_ccf713e606c2f70c) }
+
+var fileDescriptor_ccf713e606c2f70c = []byte{
+	// 276 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0x8f, 0xcd, 0x4a, 0xf4, 0x30,
+	0x18, 0x85, 0x27, 0xdf, 0x7c, 0xf6, 0x27, 0xba, 0x2a, 0x08, 0x45, 0x98, 0x74, 0x50, 0x90, 0x71,
+	0xe1, 0xcc, 0xc2, 0x2b, 0x70, 0xe8, 0x2c, 0xb2, 0x52, 0x8a, 0xe2, 0xb2, 0xb4, 0x4d, 0xec, 0x04,
+	0xed, 0xa4, 0xa4, 0x6f, 0xfd, 0xb9, 0x0b, 0x2f, 0xcb, 0xe5, 0xac, 0xc4, 0x55, 0x91, 0xf4, 0x46,
+	0x24, 0x69, 0xc1, 0x5d, 0xdf, 0xf3, 0x3c, 0x3d, 0x87, 0xe0, 0xd9, 0x6b, 0x26, 0xe0, 0x59, 0x34,
+	0x90, 0xd6, 0x4a, 0x56, 0x12, 0x38, 0x4b, 0xf9, 0x0b, 0xdf, 0xc1, 0xb2, 0x56, 0x12, 0x64, 0xe0,
+	0x34, 0xc5, 0x96, 0x57, 0xd9, 0xc9, 0x65, 0x29, 0x60, 0xdb, 0xe6, 0xcb, 0x42, 0x56, 0xab, 0x52,
+	0x96, 0x72, 0x65, 0x71, 0xde, 0x3e, 0xda, 0xcb, 0x1e, 0xf6, 0x6b, 0xf8, 0xed, 0xf4, 0x0b, 0xe1,
+	0xe3, 0x87, 0xb1, 0xf8, 0x76, 0xec, 0xdd, 0x98, 0xda, 0xe0, 0x1c, 0x7b, 0x7c, 0x07, 0xea, 0x3d,
+	0x15, 0x2c, 0x44, 0x73, 0xb4, 0x98, 0xae, 0x0f, 0x75, 0x17, 0xb9, 0x1b, 0x93, 0xd1, 0x38, 0x71,
+	0x2d, 0xa4, 0x2c, 0xb8, 0xc0, 0x3e, 0x88, 0xe2, 0x89, 0x83, 0x11, 0xff, 0x59, 0xf1, 0x48, 0x77,
+	0x91, 0x77, 0x67, 0x43, 0x1a, 0x27, 0xde, 0x80, 0x29, 0x0b, 0xce, 0xb0, 0xdb, 0x36, 0x5c, 0x19,
+	0x71, 0x6a, 0x45, 0xac, 0xbb, 0xc8, 0xb9, 0x6f, 0xb8, 0xa2, 0x71, 0xe2, 0x18, 0x44, 0x99, 0xd9,
+	0x95, 0x8a, 0x0d, 0xd6, 0xff, 0xbf, 0xdd, 0x1b, 0x93, 0x99, 0x5d, 0x0b, 0x29, 0x0b, 0x66, 0x18,
+	0xf3, 0xb7, 0x5a, 0x28, 0xde, 0xa4, 0x19, 0x84, 0x07, 0x73, 0xb4, 0xf0, 0x13, 0x7f, 0x4c, 0xae,
+	0x61, 0x1d, 0x7e, 0x6a, 0x82, 0xf6, 0x9a, 0xa0, 0x1f, 0x4d, 0xd0, 0x47, 0x4f, 0x26, 0xfb, 0x9e,
+	0x4c, 0xbe, 0x7b, 0x32, 0xc9, 0x1d, 0xfb, 0xf2, 0xab, 0xdf, 0x01, 0x00, 0x80, 0xb9, 0x4c, 0x25,
+	0x51, 0x01, 0x00, 0x00,
+}
+
+func (m *WaitlistPromotedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WaitlistPromotedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WaitlistPromotedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ExpiresAt) > 0 {
+		i -= len(m.ExpiresAt)
+		copy(dAtA[i:], m.ExpiresAt)
+		i = encodeVarintWaitlistPromotedEvent(dAtA, i, uint64(len(m.ExpiresAt)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.OrderID != 0 {
+		i = encodeVarintWaitlistPromotedEvent(dAtA, i, uint64(m.OrderID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.UserID != 0 {
+		i = encodeVarintWaitlistPromotedEvent(dAtA, i, uint64(m.UserID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.TicketID != 0 {
+		i = encodeVarintWaitlistPromotedEvent(dAtA, i, uint64(m.TicketID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.EntryID != 0 {
+		i = encodeVarintWaitlistPromotedEvent(dAtA, i, uint64(m.EntryID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWaitlistPromotedEvent(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWaitlistPromotedEvent(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *WaitlistPromotedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EntryID != 0 {
+		n += 1 + sovWaitlistPromotedEvent(uint64(m.EntryID))
+	}
+	if m.TicketID != 0 {
+		n += 1 + sovWaitlistPromotedEvent(uint64(m.TicketID))
+	}
+	if m.UserID != 0 {
+		n += 1 + sovWaitlistPromotedEvent(uint64(m.UserID))
+	}
+	if m.OrderID != 0 {
+		n += 1 + sovWaitlistPromotedEvent(uint64(m.OrderID))
+	}
+	l = len(m.ExpiresAt)
+	if l > 0 {
+		n += 1 + l + sovWaitlistPromotedEvent(uint64(l))
+	}
+	return n
+}
+
+func sovWaitlistPromotedEvent(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozWaitlistPromotedEvent(x uint64) (n int) {
+	return sovWaitlistPromotedEvent(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *WaitlistPromotedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWaitlistPromotedEvent
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WaitlistPromotedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WaitlistPromotedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntryID", wireType)
+			}
+			m.EntryID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWaitlistPromotedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EntryID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TicketID", wireType)
+			}
+			m.TicketID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWaitlistPromotedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TicketID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserID", wireType)
+			}
+			m.UserID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWaitlistPromotedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderID", wireType)
+			}
+			m.OrderID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWaitlistPromotedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OrderID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWaitlistPromotedEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWaitlistPromotedEvent
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWaitlistPromotedEvent
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpiresAt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWaitlistPromotedEvent(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWaitlistPromotedEvent
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipWaitlistPromotedEvent(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowWaitlistPromotedEvent
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWaitlistPromotedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowWaitlistPromotedEvent
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthWaitlistPromotedEvent
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupWaitlistPromotedEvent
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthWaitlistPromotedEvent
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthWaitlistPromotedEvent        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowWaitlistPromotedEvent          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupWaitlistPromotedEvent = fmt.Errorf("proto: unexpected end of group")
+)
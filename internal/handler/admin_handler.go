@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+)
+
+type AdminHandler struct {
+	service.AdminService
+	requireAdmin echo.MiddlewareFunc
+}
+
+func NewAdminHandler(adminSrv service.AdminService, requireAdmin echo.MiddlewareFunc) *AdminHandler {
+	return &AdminHandler{
+		AdminService: adminSrv,
+		requireAdmin: requireAdmin,
+	}
+}
+
+func (h *AdminHandler) Route(e *echo.Echo) {
+	admin := e.Group("/api/admin", common.RequireAuth, h.requireAdmin)
+	admin.GET("/orders", h.FindOrders)
+	admin.GET("/orders/:orderID", h.ShowOrder)
+	admin.GET("/orders/:orderID/history", h.OrderHistory)
+	admin.GET("/orders/:orderID/audit", h.OrderAudit)
+	admin.POST("/orders/:orderID/cancel", h.CancelOrder)
+	admin.POST("/orders/:orderID/complete", h.CompleteOrder)
+	admin.GET("/tickets/:ticketID", h.ShowTicket)
+}
+
+func (h *AdminHandler) FindOrders(c echo.Context) error {
+	const op = "AdminHandler.FindOrders"
+	admin, err := adminPayload(c, op)
+	if err != nil {
+		return err
+	}
+
+	orderQuery := new(model.AdminOrderQuery)
+	if err := c.Bind(orderQuery); err != nil {
+		return &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid query parameters",
+			Err:     err,
+		}
+	}
+
+	if err := c.Validate(orderQuery); err != nil {
+		return err
+	}
+
+	orders, nextCursor, err := h.AdminService.FindOrders(c.Request().Context(), admin, orderQuery)
+	if err != nil {
+		return err
+	}
+
+	if nextCursor != "" {
+		c.Response().Header().Set(NextCursorHeader, nextCursor)
+	}
+
+	return common.NewResponse(http.StatusOK, "OK", orders).SendJSON(c)
+}
+
+func (h *AdminHandler) ShowOrder(c echo.Context) error {
+	const op = "AdminHandler.ShowOrder"
+	admin, orderID, err := adminOrderParams(c, op)
+	if err != nil {
+		return err
+	}
+
+	order, err := h.AdminService.ShowOrder(c.Request().Context(), admin, orderID)
+	if err != nil {
+		return err
+	}
+	setETag(c, order)
+
+	return common.NewResponse(http.StatusOK, "OK", order).SendJSON(c)
+}
+
+func (h *AdminHandler) OrderHistory(c echo.Context) error {
+	const op = "AdminHandler.OrderHistory"
+	admin, orderID, err := adminOrderParams(c, op)
+	if err != nil {
+		return err
+	}
+
+	events, err := h.AdminService.OrderHistory(c.Request().Context(), admin, orderID)
+	if err != nil {
+		return err
+	}
+
+	return common.NewResponse(http.StatusOK, "OK", events).SendJSON(c)
+}
+
+func (h *AdminHandler) OrderAudit(c echo.Context) error {
+	const op = "AdminHandler.OrderAudit"
+	admin, orderID, err := adminOrderParams(c, op)
+	if err != nil {
+		return err
+	}
+
+	actions, err := h.AdminService.OrderAudit(c.Request().Context(), admin, orderID)
+	if err != nil {
+		return err
+	}
+
+	return common.NewResponse(http.StatusOK, "OK", actions).SendJSON(c)
+}
+
+func (h *AdminHandler) CancelOrder(c echo.Context) error {
+	const op = "AdminHandler.CancelOrder"
+	admin, orderID, err := adminOrderParams(c, op)
+	if err != nil {
+		return err
+	}
+
+	adminActionDTO := new(model.AdminActionDTO)
+	if err := c.Bind(adminActionDTO); err != nil {
+		return &common.Error{Op: op, Err: err}
+	}
+
+	if err := c.Validate(adminActionDTO); err != nil {
+		return err
+	}
+
+	order, err := h.AdminService.CancelOrder(c.Request().Context(), admin, orderID, adminActionDTO.Note)
+	if err != nil {
+		return err
+	}
+	setETag(c, order)
+
+	return common.NewResponse(http.StatusOK, "OK", order).SendJSON(c)
+}
+
+func (h *AdminHandler) CompleteOrder(c echo.Context) error {
+	const op = "AdminHandler.CompleteOrder"
+	admin, orderID, err := adminOrderParams(c, op)
+	if err != nil {
+		return err
+	}
+
+	adminActionDTO := new(model.AdminActionDTO)
+	if err := c.Bind(adminActionDTO); err != nil {
+		return &common.Error{Op: op, Err: err}
+	}
+
+	if err := c.Validate(adminActionDTO); err != nil {
+		return err
+	}
+
+	order, err := h.AdminService.CompleteOrder(c.Request().Context(), admin, orderID, adminActionDTO.Note)
+	if err != nil {
+		return err
+	}
+	setETag(c, order)
+
+	return common.NewResponse(http.StatusOK, "OK", order).SendJSON(c)
+}
+
+func (h *AdminHandler) ShowTicket(c echo.Context) error {
+	const op = "AdminHandler.ShowTicket"
+	admin, err := adminPayload(c, op)
+	if err != nil {
+		return err
+	}
+
+	ticketID, err := strconv.ParseInt(c.Param("ticketID"), 10, 64)
+	if err != nil {
+		return &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid ticket Id",
+			Err:     err,
+		}
+	}
+
+	reservation, err := h.AdminService.ShowTicket(c.Request().Context(), admin, ticketID)
+	if err != nil {
+		return err
+	}
+
+	return common.NewResponse(http.StatusOK, "OK", reservation).SendJSON(c)
+}
+
+func adminPayload(c echo.Context, op string) (*common.UserPayload, error) {
+	userPayload, ok := c.Get("userPayload").(*common.UserPayload)
+	if !ok {
+		return nil, &common.Error{
+			Op:  op,
+			Err: errors.New("missing payload in context"),
+		}
+	}
+
+	return userPayload, nil
+}
+
+func adminOrderParams(c echo.Context, op string) (*common.UserPayload, int64, error) {
+	admin, err := adminPayload(c, op)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("orderID"), 10, 64)
+	if err != nil {
+		return nil, 0, &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid order Id",
+			Err:     err,
+		}
+	}
+
+	return admin, orderID, nil
+}
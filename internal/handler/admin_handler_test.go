@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+)
+
+func TestAdminHandler(t *testing.T) {
+	adminCookie := signIn(&common.UserPayload{ID: 16, Email: "admin@gmail.com"})
+	customerCookie := signIn(&common.UserPayload{ID: 17, Email: "customer@gmail.com"})
+
+	adminAction := func(cookie *http.Cookie, path, note string) *httptest.ResponseRecorder {
+		actionJSON, _ := json.Marshal(model.AdminActionDTO{Note: note})
+		request := httptest.NewRequest(http.MethodPost, path, bytes.NewBuffer(actionJSON))
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		return response
+	}
+
+	completedOrderID := placeOrder(t, customerCookie).ID
+	cancelledOrder := placeOrder(t, customerCookie)
+	cancelledOrderID := cancelledOrder.ID
+
+	t.Run("access admin routes as a customer", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/admin/orders", nil)
+		request.AddCookie(customerCookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusForbidden, response.Code)
+
+		response = adminAction(customerCookie, fmt.Sprintf("/api/admin/orders/%d/cancel", cancelledOrderID), "no")
+		assertResponseCode(t, http.StatusForbidden, response.Code)
+	})
+
+	t.Run("search orders of another user", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/admin/orders?userId=17", nil)
+		request.AddCookie(adminCookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data []*entity.Order `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		if len(apiResponse.Data) != 2 {
+			t.Errorf("expecting 2 orders of user 17 but got %d instead", len(apiResponse.Data))
+		}
+	})
+
+	t.Run("force complete an order", func(t *testing.T) {
+		response := adminAction(adminCookie, fmt.Sprintf("/api/admin/orders/%d/complete", completedOrderID), "paid at the box office")
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data *entity.Order `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		if apiResponse.Data.Status != "COMPLETED" {
+			t.Errorf("expecting status to be 'COMPLETED' but got %q instead", apiResponse.Data.Status)
+		}
+	})
+
+	t.Run("force cancel an order without a note", func(t *testing.T) {
+		response := adminAction(adminCookie, fmt.Sprintf("/api/admin/orders/%d/cancel", cancelledOrderID), "")
+		assertResponseCode(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("force cancel an order releases its ticket", func(t *testing.T) {
+		response := adminAction(adminCookie, fmt.Sprintf("/api/admin/orders/%d/cancel", cancelledOrderID), "fraud")
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/tickets/%d", cancelledOrder.Items[0].Ticket.ID), nil)
+		request.AddCookie(adminCookie)
+		response = httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data *entity.TicketReservation `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		if len(apiResponse.Data.Orders) != 0 {
+			t.Errorf("expecting ticket %d to be available but it is held by %d orders", cancelledOrder.Items[0].Ticket.ID, len(apiResponse.Data.Orders))
+		}
+	})
+
+	t.Run("admin actions are audited", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/admin/orders/%d/audit", cancelledOrderID), nil)
+		request.AddCookie(adminCookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data []*entity.AdminAction `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		want := []string{"CANCEL_ORDER", "VIEW_ORDER"}
+		if len(apiResponse.Data) != len(want) {
+			t.Fatalf("expecting %d audit entries but got %d instead", len(want), len(apiResponse.Data))
+		}
+		for i, action := range apiResponse.Data {
+			if action.Action != want[i] || action.AdminEmail != "admin@gmail.com" {
+				t.Errorf("expecting entry %d to be %s by admin@gmail.com but got %s by %s instead", i, want[i], action.Action, action.AdminEmail)
+			}
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+)
+
+var errorCodeStatuses = map[string]int{
+	constant.EPRECONDITION:  http.StatusPreconditionFailed,
+	constant.EACTIVELIMIT:   http.StatusConflict,
+	constant.EPURCHASELIMIT: http.StatusConflict,
+	constant.ECOOLDOWN:      http.StatusTooManyRequests,
+	constant.EFORBIDDEN:     http.StatusForbidden,
+	constant.EKEYREUSED:     http.StatusUnprocessableEntity,
+}
+
+// ErrorHandler extends common.CustomErrorHandler with the error codes defined
+// in this service.
+func ErrorHandler(err error, c echo.Context) {
+	statusCode, ok := errorCodeStatuses[common.ErrorCode(err)]
+	if !ok {
+		common.CustomErrorHandler(err, c)
+		return
+	}
+
+	log.Println(err.Error())
+	c.JSON(statusCode, &common.ErrorResponse{
+		BaseResponse: &common.BaseResponse{
+			Status:  statusCode,
+			Message: common.ErrorCode(err),
+		},
+		Errors: []string{common.ErrorMessage(err)},
+	})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/health"
+)
+
+type HealthHandler struct {
+	*health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		Checker: checker,
+	}
+}
+
+func (h *HealthHandler) Route(e *echo.Echo) {
+	e.GET("/healthz", h.Live)
+	e.GET("/readyz", h.Ready)
+}
+
+// Live only reports that the process is able to serve requests.
+func (h *HealthHandler) Live(c echo.Context) error {
+	return common.NewResponse(http.StatusOK, "OK", nil).SendJSON(c)
+}
+
+func (h *HealthHandler) Ready(c echo.Context) error {
+	report := h.Checker.Run(c.Request().Context())
+	if report.Status != health.StatusUp {
+		return common.NewResponse(http.StatusServiceUnavailable, "Service Unavailable", report).SendJSON(c)
+	}
+
+	return common.NewResponse(http.StatusOK, "OK", report).SendJSON(c)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/muktiarafi/ticketing-orders/internal/health"
+)
+
+func TestHealthHandler(t *testing.T) {
+	t.Run("liveness", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("readiness reports every dependency", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data *health.Report `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		if len(apiResponse.Data.Checks) != 2 {
+			t.Fatalf("expecting 2 checks but got %d instead", len(apiResponse.Data.Checks))
+		}
+
+		for _, check := range apiResponse.Data.Checks {
+			if check.Status != health.StatusUp {
+				t.Errorf("expecting %s to be up but got %q: %s", check.Name, check.Status, check.Error)
+			}
+		}
+	})
+}
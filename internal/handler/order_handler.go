@@ -1,32 +1,51 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
 	"github.com/muktiarafi/ticketing-orders/internal/model"
 	"github.com/muktiarafi/ticketing-orders/internal/service"
 )
 
+const (
+	// NextCursorHeader carries the cursor of the next page of orders. It is
+	// absent on the last page.
+	NextCursorHeader = "X-Next-Cursor"
+	ETagHeader       = "ETag"
+	IfMatchHeader    = "If-Match"
+)
+
 type OrderHandler struct {
 	service.OrderService
+	idempotency echo.MiddlewareFunc
 }
 
-func NewOrderHandler(orderSrv service.OrderService) *OrderHandler {
+func NewOrderHandler(orderSrv service.OrderService, idempotency echo.MiddlewareFunc) *OrderHandler {
 	return &OrderHandler{
 		OrderService: orderSrv,
+		idempotency:  idempotency,
 	}
 }
 
 func (h *OrderHandler) Route(e *echo.Echo) {
 	orders := e.Group("/api/orders", common.RequireAuth)
-	orders.POST("", h.Create)
+	orders.POST("", h.Create, h.idempotency)
 	orders.GET("", h.GetAll)
 	orders.GET("/:orderID", h.Show)
 	orders.PUT("/:orderID", h.Update)
+	orders.PATCH("/:orderID", h.Patch)
+	orders.POST("/:orderID/cancel", h.Cancel)
+	orders.POST("/:orderID/refund", h.RequestRefund)
+	orders.GET("/:orderID/history", h.History)
 }
 
 func (h *OrderHandler) Create(c echo.Context) error {
@@ -48,10 +67,11 @@ func (h *OrderHandler) Create(c echo.Context) error {
 		return err
 	}
 
-	order, err := h.OrderService.Create(int64(userPayload.ID), orderDTO.TicketID)
+	order, err := h.OrderService.Create(c.Request().Context(), int64(userPayload.ID), orderDTO)
 	if err != nil {
 		return err
 	}
+	setETag(c, order)
 
 	return common.NewResponse(http.StatusCreated, "Created", order).SendJSON(c)
 }
@@ -66,11 +86,29 @@ func (h *OrderHandler) GetAll(c echo.Context) error {
 		}
 	}
 
-	orders, err := h.OrderService.Find(int64(userPayload.ID))
+	orderQuery := new(model.OrderQuery)
+	if err := c.Bind(orderQuery); err != nil {
+		return &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid query parameters",
+			Err:     err,
+		}
+	}
+
+	if err := c.Validate(orderQuery); err != nil {
+		return err
+	}
+
+	orders, nextCursor, err := h.OrderService.Find(c.Request().Context(), int64(userPayload.ID), orderQuery)
 	if err != nil {
 		return err
 	}
 
+	if nextCursor != "" {
+		c.Response().Header().Set(NextCursorHeader, nextCursor)
+	}
+
 	return common.NewResponse(http.StatusOK, "OK", orders).SendJSON(c)
 }
 
@@ -94,14 +132,17 @@ func (h *OrderHandler) Show(c echo.Context) error {
 			Err:     err,
 		}
 	}
-	order, err := h.OrderService.Show(int64(userPayload.ID), orderID)
+	order, err := h.OrderService.Show(c.Request().Context(), int64(userPayload.ID), orderID)
 	if err != nil {
 		return err
 	}
+	setETag(c, order)
 
 	return common.NewResponse(http.StatusOK, "OK", order).SendJSON(c)
 }
 
+// Update cancels the order with reason OTHER. It predates Cancel and is kept
+// for existing clients.
 func (h *OrderHandler) Update(c echo.Context) error {
 	userPayload, ok := c.Get("userPayload").(*common.UserPayload)
 	const op = "OrderHandler.Show"
@@ -123,10 +164,219 @@ func (h *OrderHandler) Update(c echo.Context) error {
 		}
 	}
 
-	order, err := h.OrderService.Update(int64(userPayload.ID), orderID)
+	version, err := ifMatchVersion(c)
 	if err != nil {
 		return err
 	}
 
+	order, err := h.OrderService.Cancel(c.Request().Context(), int64(userPayload.ID), orderID, version, &entity.Cancellation{
+		ReasonCode: constant.ReasonOther,
+	})
+	if err != nil {
+		return err
+	}
+	setETag(c, order)
+
 	return common.NewResponse(http.StatusOK, "OK", order).SendJSON(c)
 }
+
+func (h *OrderHandler) Cancel(c echo.Context) error {
+	userPayload, ok := c.Get("userPayload").(*common.UserPayload)
+	const op = "OrderHandler.Cancel"
+	if !ok {
+		return &common.Error{
+			Op:  op,
+			Err: errors.New("missing payload in context"),
+		}
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("orderID"), 10, 64)
+	if err != nil {
+		return &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid order Id",
+			Err:     err,
+		}
+	}
+
+	cancelOrderDTO := new(model.CancelOrderDTO)
+	if err := c.Bind(cancelOrderDTO); err != nil {
+		return &common.Error{Op: op, Err: err}
+	}
+
+	if err := c.Validate(cancelOrderDTO); err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	order, err := h.OrderService.Cancel(c.Request().Context(), int64(userPayload.ID), orderID, version, &entity.Cancellation{
+		ReasonCode: cancelOrderDTO.ReasonCode,
+		Note:       cancelOrderDTO.Note,
+	})
+	if err != nil {
+		return err
+	}
+	setETag(c, order)
+
+	return common.NewResponse(http.StatusOK, "OK", order).SendJSON(c)
+}
+
+// RequestRefund cancels a paid order. The refund itself is confirmed
+// asynchronously by the payment service.
+func (h *OrderHandler) RequestRefund(c echo.Context) error {
+	userPayload, ok := c.Get("userPayload").(*common.UserPayload)
+	const op = "OrderHandler.RequestRefund"
+	if !ok {
+		return &common.Error{
+			Op:  op,
+			Err: errors.New("missing payload in context"),
+		}
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("orderID"), 10, 64)
+	if err != nil {
+		return &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid order Id",
+			Err:     err,
+		}
+	}
+
+	cancelOrderDTO := new(model.CancelOrderDTO)
+	if err := c.Bind(cancelOrderDTO); err != nil {
+		return &common.Error{Op: op, Err: err}
+	}
+
+	if err := c.Validate(cancelOrderDTO); err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	order, err := h.OrderService.RequestRefund(c.Request().Context(), int64(userPayload.ID), orderID, version, &entity.Cancellation{
+		ReasonCode: cancelOrderDTO.ReasonCode,
+		Note:       cancelOrderDTO.Note,
+	})
+	if err != nil {
+		return err
+	}
+	setETag(c, order)
+
+	return common.NewResponse(http.StatusAccepted, "Accepted", order).SendJSON(c)
+}
+
+// Patch rejects fields that are unknown or may not be changed, instead of
+// silently ignoring them.
+func (h *OrderHandler) Patch(c echo.Context) error {
+	userPayload, ok := c.Get("userPayload").(*common.UserPayload)
+	const op = "OrderHandler.Patch"
+	if !ok {
+		return &common.Error{
+			Op:  op,
+			Err: errors.New("missing payload in context"),
+		}
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("orderID"), 10, 64)
+	if err != nil {
+		return &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid order Id",
+			Err:     err,
+		}
+	}
+
+	orderPatchDTO := new(model.OrderPatchDTO)
+	decoder := json.NewDecoder(c.Request().Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(orderPatchDTO); err != nil {
+		return &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid order patch",
+			Err:     err,
+		}
+	}
+
+	if err := c.Validate(orderPatchDTO); err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	order, err := h.OrderService.Patch(c.Request().Context(), int64(userPayload.ID), orderID, version, orderPatchDTO)
+	if err != nil {
+		return err
+	}
+	setETag(c, order)
+
+	return common.NewResponse(http.StatusOK, "OK", order).SendJSON(c)
+}
+
+func (h *OrderHandler) History(c echo.Context) error {
+	userPayload, ok := c.Get("userPayload").(*common.UserPayload)
+	const op = "OrderHandler.History"
+	if !ok {
+		return &common.Error{
+			Op:  op,
+			Err: errors.New("missing payload in context"),
+		}
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("orderID"), 10, 64)
+	if err != nil {
+		return &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid order Id",
+			Err:     err,
+		}
+	}
+
+	history, err := h.OrderService.History(c.Request().Context(), int64(userPayload.ID), orderID)
+	if err != nil {
+		return err
+	}
+
+	return common.NewResponse(http.StatusOK, "OK", history).SendJSON(c)
+}
+
+// setETag exposes the order version so that clients can send it back in
+// If-Match when changing the order.
+func setETag(c echo.Context, order *entity.Order) {
+	c.Response().Header().Set(ETagHeader, strconv.Quote(strconv.FormatInt(order.Version, 10)))
+}
+
+// ifMatchVersion returns the order version required by the If-Match header,
+// or zero when the header is absent or "*".
+func ifMatchVersion(c echo.Context) (int64, error) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get(IfMatchHeader))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`), 10, 64)
+	if err != nil || version < 1 {
+		return 0, &common.Error{
+			Code:    constant.EPRECONDITION,
+			Op:      "OrderHandler.ifMatchVersion",
+			Message: "If-Match does not match the order version",
+			Err:     fmt.Errorf("malformed If-Match header %q", ifMatch),
+		}
+	}
+
+	return version, nil
+}
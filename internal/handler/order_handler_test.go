@@ -2,16 +2,25 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-common/types"
+	"github.com/muktiarafi/ticketing-orders/internal/config"
 	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
 	"github.com/muktiarafi/ticketing-orders/internal/model"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
+	"github.com/muktiarafi/ticketing-orders/internal/testutil"
 )
 
 func TestOrderHandlerCreate(t *testing.T) {
@@ -22,9 +31,9 @@ func TestOrderHandlerCreate(t *testing.T) {
 		ticket := &entity.Ticket{
 			ID:    1,
 			Title: "ticket",
-			Price: 12,
+			Price: money.New(1200, "USD"),
 		}
-		newTicket, err := ticketRepo.Insert(ticket)
+		newTicket, err := ticketRepo.Insert(context.Background(), ticket)
 		if err != nil {
 			t.Error(err)
 		}
@@ -32,6 +41,7 @@ func TestOrderHandlerCreate(t *testing.T) {
 			TicketID: newTicket.ID,
 		}
 		orderDTOJSON, _ := json.Marshal(orderDTO)
+		outboxCount := countOutboxMessages(t, common.OrderCreated)
 
 		request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
 		request.Header.Set("Content-Type", "application/json")
@@ -51,13 +61,29 @@ func TestOrderHandlerCreate(t *testing.T) {
 		if apiResponse.Data.Status != "CREATED" {
 			t.Errorf("expecting status to be 'CREATED' but got %q instead", apiResponse.Data.Status)
 		}
+
+		got := countOutboxMessages(t, common.OrderCreated)
+		if got != outboxCount+1 {
+			t.Errorf("expecting order created event to be written to the outbox, got %d messages instead of %d", got, outboxCount+1)
+		}
+
+		wantExpiresAt := testClock.Now().Add(testExpiration.Default)
+		if !apiResponse.Data.ExpiresAt.Equal(wantExpiresAt) {
+			t.Errorf("expecting order to expire at %v but got %v instead", wantExpiresAt, apiResponse.Data.ExpiresAt)
+		}
 	})
 
-	t.Run("create order with nonexistent ticket", func(t *testing.T) {
-		orderDTO := model.OrderDTO{
-			TicketID: 9999991,
+	t.Run("create order for ticket with its own expiration", func(t *testing.T) {
+		ticket := &entity.Ticket{
+			ID:    23,
+			Title: "ticket",
+			Price: money.New(1200, "USD"),
 		}
-		orderDTOJSON, _ := json.Marshal(orderDTO)
+		newTicket, err := ticketRepo.Insert(context.Background(), ticket)
+		if err != nil {
+			t.Error(err)
+		}
+		orderDTOJSON, _ := json.Marshal(model.OrderDTO{TicketID: newTicket.ID})
 
 		request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
 		request.Header.Set("Content-Type", "application/json")
@@ -66,60 +92,107 @@ func TestOrderHandlerCreate(t *testing.T) {
 
 		router.ServeHTTP(response, request)
 
-		assertResponseCode(t, http.StatusNotFound, response.Code)
-	})
-}
-
-func TestOrderHandlerGetAll(t *testing.T) {
-	user := &common.UserPayload{2, "bambank@gmail.com"}
-	cookie := signIn(user)
-
-	t.Run("get all order when not ordering ticket", func(t *testing.T) {
-		request := httptest.NewRequest(http.MethodGet, "/api/orders", nil)
-		request.AddCookie(cookie)
-		response := httptest.NewRecorder()
-		router.ServeHTTP(response, request)
-
-		assertResponseCode(t, http.StatusOK, response.Code)
+		assertResponseCode(t, http.StatusCreated, response.Code)
 
 		responseBody, _ := ioutil.ReadAll(response.Body)
 		apiResponse := struct {
-			Data []*entity.Order `json:"data"`
+			Data *entity.Order `json:"data"`
 		}{}
 		json.Unmarshal(responseBody, &apiResponse)
 
-		got := len(apiResponse.Data)
-		want := 0
-		if got != want {
-			t.Errorf("expecting the number of orders to be %d but got %d instead", want, got)
+		wantExpiresAt := testClock.Now().Add(testExpiration.Tickets[23])
+		if !apiResponse.Data.ExpiresAt.Equal(wantExpiresAt) {
+			t.Errorf("expecting order to expire at %v but got %v instead", wantExpiresAt, apiResponse.Data.ExpiresAt)
 		}
 	})
 
-	t.Run("get all orders after ordering some tickets", func(t *testing.T) {
+	t.Run("create order with several tickets", func(t *testing.T) {
 		tickets := []*entity.Ticket{
 			{
-				ID:    2,
-				Title: "a",
-				Price: 2,
+				ID:    40,
+				Title: "ticket",
+				Price: money.New(1000, "USD"),
 			},
 			{
-				ID:    3,
-				Title: "b",
-				Price: 2,
+				ID:    41,
+				Title: "ticket",
+				Price: money.New(1500, "USD"),
 			},
-			{
-				ID:    4,
-				Title: "c",
-				Price: 2,
+		}
+		for _, v := range tickets {
+			if _, err := ticketRepo.Insert(context.Background(), v); err != nil {
+				t.Error(err)
+			}
+		}
+		orderDTOJSON, _ := json.Marshal(model.OrderDTO{
+			Items: []*model.OrderItemDTO{
+				{TicketID: 41},
+				{TicketID: 40, Quantity: 1},
 			},
+		})
+		outboxCount := countOutboxMessages(t, common.OrderCreated)
+
+		request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assertResponseCode(t, http.StatusCreated, response.Code)
+
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data *entity.Order `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		if len(apiResponse.Data.Items) != 2 {
+			t.Errorf("expecting order to have 2 items but got %d instead", len(apiResponse.Data.Items))
+		}
+		if apiResponse.Data.Total != money.New(2500, "USD") {
+			t.Errorf("expecting order total to be 25.00 USD but got %v instead", apiResponse.Data.Total)
 		}
 
-		for _, v := range tickets {
-			ticketRepo.Insert(v)
+		got := countOutboxMessages(t, common.OrderCreated)
+		if got != outboxCount+2 {
+			t.Errorf("expecting one order created event per item, got %d messages instead of %d", got, outboxCount+2)
+		}
+
+		stmt := `SELECT payload, metadata->>'order_total' FROM outbox WHERE topic = $1 ORDER BY id DESC LIMIT 2`
+		rows, err := db.SQL.Query(stmt, common.OrderCreated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		prices := make(map[int64]float64)
+		for rows.Next() {
+			var payload []byte
+			var total string
+			if err := rows.Scan(&payload, &total); err != nil {
+				t.Fatal(err)
+			}
+			orderCreatedData := new(types.OrderCreatedEvent)
+			if err := orderCreatedData.Unmarshal(payload); err != nil {
+				t.Fatal(err)
+			}
+			prices[orderCreatedData.TicketID] = orderCreatedData.TicketPrice
+			if total != "25.00" {
+				t.Errorf("expecting order total 25.00 in the metadata but got %q instead", total)
+			}
+		}
+		if want := map[int64]float64{40: 10, 41: 15}; !reflect.DeepEqual(prices, want) {
+			t.Errorf("expecting each event to carry its own ticket price %v but got %v instead", want, prices)
+		}
+	})
 
-			orderDTO := &model.OrderDTO{
-				TicketID: v.ID,
+	t.Run("create order with an already reserved ticket among others", func(t *testing.T) {
+		for _, id := range []int64{42, 43} {
+			if _, err := ticketRepo.Insert(context.Background(), &entity.Ticket{ID: id, Title: "ticket", Price: money.New(500, "USD")}); err != nil {
+				t.Error(err)
 			}
+		}
+		postOrder := func(orderDTO model.OrderDTO) int {
 			orderDTOJSON, _ := json.Marshal(orderDTO)
 			request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
 			request.Header.Set("Content-Type", "application/json")
@@ -128,15 +201,147 @@ func TestOrderHandlerGetAll(t *testing.T) {
 
 			router.ServeHTTP(response, request)
 
-			assertResponseCode(t, http.StatusCreated, response.Code)
+			return response.Code
+		}
+
+		assertResponseCode(t, http.StatusCreated, postOrder(model.OrderDTO{TicketID: 42}))
+		assertResponseCode(t, http.StatusBadRequest, postOrder(model.OrderDTO{
+			Items: []*model.OrderItemDTO{{TicketID: 42}, {TicketID: 43}},
+		}))
+		// ticket 43 must not be held by the rejected order
+		assertResponseCode(t, http.StatusCreated, postOrder(model.OrderDTO{TicketID: 43}))
+	})
+
+	t.Run("create order in a currency the ticket is not priced in", func(t *testing.T) {
+		if _, err := ticketRepo.Insert(context.Background(), &entity.Ticket{
+			ID:    45,
+			Title: "ticket",
+			Price: money.New(1000, "USD"),
+		}); err != nil {
+			t.Error(err)
 		}
+		orderDTOJSON, _ := json.Marshal(model.OrderDTO{TicketID: 45, Currency: "EUR"})
 
-		request := httptest.NewRequest(http.MethodGet, "/api/orders", nil)
+		request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
+		request.Header.Set("Content-Type", "application/json")
 		request.AddCookie(cookie)
 		response := httptest.NewRecorder()
+
 		router.ServeHTTP(response, request)
 
-		assertResponseCode(t, http.StatusOK, response.Code)
+		assertResponseCode(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("create order with nonexistent ticket", func(t *testing.T) {
+		orderDTO := model.OrderDTO{
+			TicketID: 9999991,
+		}
+		orderDTOJSON, _ := json.Marshal(orderDTO)
+
+		request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assertResponseCode(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("create orders concurrently for the same ticket", func(t *testing.T) {
+		orderDTO := model.OrderDTO{
+			TicketID: newTicket(t).ID,
+		}
+
+		const attempts = 20
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		codes := make(map[int]int)
+		for i := 0; i < attempts; i++ {
+			wg.Add(1)
+			go func(userID int) {
+				defer wg.Done()
+
+				response := postOrder(signIn(&common.UserPayload{ID: userID, Email: "bambank@gmail.com"}), orderDTO)
+
+				mu.Lock()
+				codes[response.Code]++
+				mu.Unlock()
+			}(100 + i)
+		}
+		wg.Wait()
+
+		if codes[http.StatusCreated] != 1 {
+			t.Errorf("expecting exactly one order to be created but got %d", codes[http.StatusCreated])
+		}
+
+		if codes[http.StatusBadRequest] != attempts-1 {
+			t.Errorf("expecting %d orders to be rejected but got %d", attempts-1, codes[http.StatusBadRequest])
+		}
+	})
+}
+
+func TestOrderHandlerCreateIdempotency(t *testing.T) {
+	user := &common.UserPayload{ID: 5, Email: "bambank@gmail.com"}
+	cookie := signIn(user)
+	postOrderWithKey := func(ticketID int64, key string) *httptest.ResponseRecorder {
+		orderDTOJSON, _ := json.Marshal(model.OrderDTO{TicketID: ticketID})
+		request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Idempotency-Key", key)
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		return response
+	}
+
+	t.Run("replay order creation with the same key", func(t *testing.T) {
+		ticket := newTicket(t)
+
+		orderIDs := make([]int64, 0)
+		for i := 0; i < 2; i++ {
+			response := postOrderWithKey(ticket.ID, "replay-key")
+
+			assertResponseCode(t, http.StatusCreated, response.Code)
+			orderIDs = append(orderIDs, decodeOrder(t, response).ID)
+		}
+
+		if orderIDs[0] != orderIDs[1] {
+			t.Errorf("expecting replay to return order %d but got %d instead", orderIDs[0], orderIDs[1])
+		}
+	})
+
+	t.Run("reuse key with a different payload", func(t *testing.T) {
+		assertResponseCode(t, http.StatusCreated, postOrderWithKey(newTicket(t).ID, "reused-key").Code)
+
+		response := postOrderWithKey(newTicket(t).ID, "reused-key")
+
+		assertResponseCode(t, http.StatusUnprocessableEntity, response.Code)
+	})
+
+	t.Run("take over a key abandoned past its lease", func(t *testing.T) {
+		stmt := `INSERT INTO idempotency_keys (user_id, key, request_hash, created_at)
+		VALUES ($1, 'abandoned-key', 'abandoned', NOW() - INTERVAL '2 minutes')`
+		if _, err := db.SQL.Exec(stmt, user.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		response := postOrderWithKey(newTicket(t).ID, "abandoned-key")
+
+		assertResponseCode(t, http.StatusCreated, response.Code)
+	})
+}
+
+func TestOrderHandlerGetAll(t *testing.T) {
+	user := &common.UserPayload{2, "bambank@gmail.com"}
+	cookie := signIn(user)
+	getOrders := func(query string) ([]*entity.Order, *httptest.ResponseRecorder) {
+		request := httptest.NewRequest(http.MethodGet, "/api/orders"+query, nil)
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
 
 		responseBody, _ := ioutil.ReadAll(response.Body)
 		apiResponse := struct {
@@ -144,12 +349,82 @@ func TestOrderHandlerGetAll(t *testing.T) {
 		}{}
 		json.Unmarshal(responseBody, &apiResponse)
 
-		got := len(apiResponse.Data)
+		return apiResponse.Data, response
+	}
+
+	t.Run("get all order when not ordering ticket", func(t *testing.T) {
+		orders, response := getOrders("")
+
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		got := len(orders)
+		want := 0
+		if got != want {
+			t.Errorf("expecting the number of orders to be %d but got %d instead", want, got)
+		}
+	})
+
+	var ticketIDs []int64
+	t.Run("get all orders after ordering some tickets", func(t *testing.T) {
+		for _, title := range []string{"a", "b", "c"} {
+			ticket := testutil.InsertTicket(t, ticketRepo, title, money.New(200, "USD"))
+			createOrder(t, cookie, model.OrderDTO{TicketID: ticket.ID})
+			ticketIDs = append(ticketIDs, ticket.ID)
+		}
+
+		orders, response := getOrders("")
+
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		got := len(orders)
 		want := 3
 		if got != want {
 			t.Errorf("expecting the number of orders to be %d but got %d instead", want, got)
 		}
 	})
+
+	t.Run("page through orders with a cursor", func(t *testing.T) {
+		var got []int64
+		cursor := ""
+		for page := 0; page < 3; page++ {
+			orders, response := getOrders("?limit=2&sort=-id&cursor=" + cursor)
+
+			assertResponseCode(t, http.StatusOK, response.Code)
+
+			for _, order := range orders {
+				got = append(got, order.Items[0].Ticket.ID)
+			}
+
+			cursor = response.Header().Get(NextCursorHeader)
+			if cursor == "" {
+				break
+			}
+		}
+
+		want := []int64{ticketIDs[2], ticketIDs[1], ticketIDs[0]}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expecting orders for tickets %v but got %v instead", want, got)
+		}
+	})
+
+	t.Run("filter orders by status and ticket", func(t *testing.T) {
+		orders, response := getOrders(fmt.Sprintf("?status=CREATED&ticketId=%d", ticketIDs[1]))
+
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		if len(orders) != 1 || orders[0].Items[0].Ticket.ID != ticketIDs[1] {
+			t.Errorf("expecting only the order for ticket %d but got %d orders instead", ticketIDs[1], len(orders))
+		}
+	})
+
+	t.Run("reject cursor issued for a different sort", func(t *testing.T) {
+		_, response := getOrders("?limit=1&sort=-id")
+		cursor := response.Header().Get(NextCursorHeader)
+
+		_, response = getOrders("?limit=1&sort=createdAt&cursor=" + cursor)
+
+		assertResponseCode(t, http.StatusBadRequest, response.Code)
+	})
 }
 
 func TestOrderHandlerShow(t *testing.T) {
@@ -169,9 +444,9 @@ func TestOrderHandlerShow(t *testing.T) {
 		ticket := &entity.Ticket{
 			ID:    6,
 			Title: "ticket",
-			Price: 12,
+			Price: money.New(1200, "USD"),
 		}
-		newTicket, err := ticketRepo.Insert(ticket)
+		newTicket, err := ticketRepo.Insert(context.Background(), ticket)
 		if err != nil {
 			t.Error(err)
 		}
@@ -213,6 +488,63 @@ func TestOrderHandlerShow(t *testing.T) {
 			t.Errorf("expecting order id to be %d but got %d instead", orderID, got)
 		}
 	})
+
+	t.Run("show order keeps the price it was reserved at", func(t *testing.T) {
+		ticket, err := ticketRepo.Insert(context.Background(), &entity.Ticket{
+			ID:    44,
+			Title: "before",
+			Price: money.New(2000, "USD"),
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		orderDTOJSON, _ := json.Marshal(model.OrderDTO{TicketID: ticket.ID})
+
+		request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusCreated, response.Code)
+
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data *entity.Order `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+		orderID := apiResponse.Data.ID
+
+		ticket.Title = "after"
+		ticket.Price = money.New(3500, "USD")
+		if _, err := ticketRepo.Update(context.Background(), ticket); err != nil {
+			t.Error(err)
+		}
+
+		request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/orders/%d", orderID), nil)
+		request.AddCookie(cookie)
+		response = httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		responseBody, _ = ioutil.ReadAll(response.Body)
+		apiResponse = struct {
+			Data *entity.Order `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		item := apiResponse.Data.Items[0]
+		if item.Title != "before" || item.UnitPrice != money.New(2000, "USD") {
+			t.Errorf("expecting snapshot of 'before' at 20.00 USD but got %q at %v instead", item.Title, item.UnitPrice)
+		}
+		if item.Ticket.Price != money.New(3500, "USD") {
+			t.Errorf("expecting current ticket price to be 35.00 USD but got %v instead", item.Ticket.Price)
+		}
+		if apiResponse.Data.Total != money.New(2000, "USD") {
+			t.Errorf("expecting order total to be 20.00 USD but got %v instead", apiResponse.Data.Total)
+		}
+	})
 }
 
 func TestOrderHandlerUpdate(t *testing.T) {
@@ -223,9 +555,9 @@ func TestOrderHandlerUpdate(t *testing.T) {
 		ticket := &entity.Ticket{
 			ID:    9,
 			Title: "ticket",
-			Price: 12,
+			Price: money.New(1200, "USD"),
 		}
-		newTicket, err := ticketRepo.Insert(ticket)
+		newTicket, err := ticketRepo.Insert(context.Background(), ticket)
 		if err != nil {
 			t.Error(err)
 		}
@@ -276,6 +608,92 @@ func TestOrderHandlerUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("update with If-Match", func(t *testing.T) {
+		if _, err := ticketRepo.Insert(context.Background(), &entity.Ticket{
+			ID:    47,
+			Title: "ticket",
+			Price: money.New(1200, "USD"),
+		}); err != nil {
+			t.Error(err)
+		}
+		orderDTOJSON, _ := json.Marshal(model.OrderDTO{TicketID: 47})
+
+		request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusCreated, response.Code)
+
+		etag := response.Header().Get("ETag")
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data *entity.Order `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+		orderURL := fmt.Sprintf("/api/orders/%d", apiResponse.Data.ID)
+
+		request = httptest.NewRequest(http.MethodPut, orderURL, nil)
+		request.Header.Set("If-Match", `"99"`)
+		request.AddCookie(cookie)
+		response = httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusPreconditionFailed, response.Code)
+
+		request = httptest.NewRequest(http.MethodPut, orderURL, nil)
+		request.Header.Set("If-Match", etag)
+		request.AddCookie(cookie)
+		response = httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		if response.Header().Get("ETag") == etag {
+			t.Errorf("expecting ETag to change after the update but got %s again", etag)
+		}
+	})
+
+	t.Run("update already cancelled order", func(t *testing.T) {
+		ticket := &entity.Ticket{
+			ID:    10,
+			Title: "ticket",
+			Price: money.New(1200, "USD"),
+		}
+		newTicket, err := ticketRepo.Insert(context.Background(), ticket)
+		if err != nil {
+			t.Error(err)
+		}
+		orderDTO := model.OrderDTO{
+			TicketID: newTicket.ID,
+		}
+		orderDTOJSON, _ := json.Marshal(orderDTO)
+
+		request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assertResponseCode(t, http.StatusCreated, response.Code)
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data *entity.Order `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		for _, want := range []int{http.StatusOK, http.StatusConflict} {
+			request = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/orders/%d", apiResponse.Data.ID), nil)
+			request.AddCookie(cookie)
+			response = httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+			assertResponseCode(t, want, response.Code)
+		}
+	})
+
 	t.Run("update nonexistent order", func(t *testing.T) {
 		request := httptest.NewRequest(http.MethodPut, "/api/orders/1312312", nil)
 		request.AddCookie(cookie)
@@ -285,3 +703,254 @@ func TestOrderHandlerUpdate(t *testing.T) {
 		assertResponseCode(t, http.StatusNotFound, response.Code)
 	})
 }
+
+func TestOrderHandlerHistory(t *testing.T) {
+	user := &common.UserPayload{ID: 7, Email: "bambank@gmail.com"}
+	cookie := signIn(user)
+
+	ticket, err := ticketRepo.Insert(context.Background(), &entity.Ticket{
+		ID:    46,
+		Title: "ticket",
+		Price: money.New(1200, "USD"),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	orderDTOJSON, _ := json.Marshal(model.OrderDTO{TicketID: ticket.ID})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
+	request.Header.Set("Content-Type", "application/json")
+	request.AddCookie(cookie)
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+	assertResponseCode(t, http.StatusCreated, response.Code)
+
+	responseBody, _ := ioutil.ReadAll(response.Body)
+	apiResponse := struct {
+		Data *entity.Order `json:"data"`
+	}{}
+	json.Unmarshal(responseBody, &apiResponse)
+	orderID := apiResponse.Data.ID
+
+	request = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/orders/%d", orderID), nil)
+	request.AddCookie(cookie)
+	response = httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+	assertResponseCode(t, http.StatusOK, response.Code)
+
+	t.Run("show history of own order", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/orders/%d/history", orderID), nil)
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data []*entity.OrderEvent `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		if len(apiResponse.Data) != 2 {
+			t.Fatalf("expecting 2 history entries but got %d instead", len(apiResponse.Data))
+		}
+
+		cancelled := apiResponse.Data[1]
+		if cancelled.FromStatus != "CREATED" || cancelled.ToStatus != "CANCELLED" {
+			t.Errorf("expecting change from CREATED to CANCELLED but got %q to %q instead", cancelled.FromStatus, cancelled.ToStatus)
+		}
+		if cancelled.ActorType != "USER" || cancelled.ActorID != int64(user.ID) {
+			t.Errorf("expecting change by user %d but got %s %d instead", user.ID, cancelled.ActorType, cancelled.ActorID)
+		}
+	})
+
+	t.Run("show history of someone else's order", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/orders/%d/history", orderID), nil)
+		request.AddCookie(signIn(&common.UserPayload{ID: 8, Email: "other@gmail.com"}))
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestOrderHandlerCancel(t *testing.T) {
+	user := &common.UserPayload{ID: 9, Email: "bambank@gmail.com"}
+	cookie := signIn(user)
+	sendOrder := func(method, path string, body []byte) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, bytes.NewBuffer(body))
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		return response
+	}
+
+	t.Run("cancel with a reason", func(t *testing.T) {
+		orderID := placeOrder(t, cookie).ID
+		cancelJSON, _ := json.Marshal(model.CancelOrderDTO{ReasonCode: "CHANGED_MIND", Note: "wrong date"})
+
+		response := sendOrder(http.MethodPost, fmt.Sprintf("/api/orders/%d/cancel", orderID), cancelJSON)
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		order := decodeOrder(t, response)
+		cancellation := order.Cancellation
+		if order.Status != "CANCELLED" || cancellation == nil || cancellation.ReasonCode != "CHANGED_MIND" || cancellation.Note != "wrong date" {
+			t.Errorf("expecting order to be cancelled for CHANGED_MIND but got %q with %+v instead", order.Status, cancellation)
+		}
+
+		var reason string
+		stmt := `SELECT metadata->>'cancellation_reason' FROM outbox WHERE topic = $1 ORDER BY id DESC LIMIT 1`
+		if err := db.SQL.QueryRow(stmt, common.OrderCancelled).Scan(&reason); err != nil {
+			t.Fatal(err)
+		}
+		if reason != "CHANGED_MIND" {
+			t.Errorf("expecting cancelled event to carry reason CHANGED_MIND but got %q instead", reason)
+		}
+	})
+
+	t.Run("cancel with an unknown reason", func(t *testing.T) {
+		orderID := placeOrder(t, cookie).ID
+		cancelJSON, _ := json.Marshal(model.CancelOrderDTO{ReasonCode: "BORED"})
+
+		response := sendOrder(http.MethodPost, fmt.Sprintf("/api/orders/%d/cancel", orderID), cancelJSON)
+		assertResponseCode(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("patch a field that may not be changed", func(t *testing.T) {
+		orderID := placeOrder(t, cookie).ID
+
+		response := sendOrder(http.MethodPatch, fmt.Sprintf("/api/orders/%d", orderID), []byte(`{"expiresAt":"2030-01-01T00:00:00Z"}`))
+		assertResponseCode(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("patch status to cancelled", func(t *testing.T) {
+		orderID := placeOrder(t, cookie).ID
+
+		response := sendOrder(http.MethodPatch, fmt.Sprintf("/api/orders/%d", orderID), []byte(`{"status":"CANCELLED","cancellation":{"reasonCode":"OTHER"}}`))
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		if order := decodeOrder(t, response); order.Status != "CANCELLED" {
+			t.Errorf("expecting status to be 'CANCELLED' but got %q instead", order.Status)
+		}
+	})
+}
+
+func TestOrderHandlerRequestRefund(t *testing.T) {
+	user := &common.UserPayload{ID: 10, Email: "bambank@gmail.com"}
+	cookie := signIn(user)
+
+	ticket := newTicket(t)
+	order := createOrder(t, cookie, model.OrderDTO{TicketID: ticket.ID})
+	if _, err := orderService.Complete(context.Background(), order.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("request refund of a completed order", func(t *testing.T) {
+		outboxCount := countOutboxMessages(t, schema.OrderRefundRequested)
+		refundJSON, _ := json.Marshal(model.CancelOrderDTO{ReasonCode: "CHANGED_MIND"})
+
+		request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/orders/%d/refund", order.ID), bytes.NewBuffer(refundJSON))
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusAccepted, response.Code)
+
+		if refundingOrder := decodeOrder(t, response); refundingOrder.Status != "REFUND_REQUESTED" {
+			t.Errorf("expecting status to be 'REFUND_REQUESTED' but got %q instead", refundingOrder.Status)
+		}
+		if got := countOutboxMessages(t, schema.OrderRefundRequested); got != outboxCount+1 {
+			t.Errorf("expecting refund requested event to be written to the outbox, got %d messages instead of %d", got, outboxCount+1)
+		}
+	})
+
+	t.Run("ticket is released only once the refund completes", func(t *testing.T) {
+		otherCookie := signIn(&common.UserPayload{ID: 11, Email: "other@gmail.com"})
+		assertResponseCode(t, http.StatusBadRequest, postOrder(otherCookie, model.OrderDTO{TicketID: ticket.ID}).Code)
+
+		refundedOrder, err := orderService.Refund(context.Background(), order.ID, "refund-event")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if refundedOrder.Status != "REFUNDED" {
+			t.Errorf("expecting status to be 'REFUNDED' but got %q instead", refundedOrder.Status)
+		}
+
+		assertResponseCode(t, http.StatusCreated, postOrder(otherCookie, model.OrderDTO{TicketID: ticket.ID}).Code)
+	})
+}
+
+func TestOrderHandlerLimits(t *testing.T) {
+	defer func() { *testLimits = config.Limits{} }()
+
+	newTitledTicket := func(t *testing.T, title string) int64 {
+		t.Helper()
+
+		return testutil.InsertTicket(t, ticketRepo, title, money.New(1000, "USD")).ID
+	}
+	orderTicket := func(cookie *http.Cookie, ticketID int64) int {
+		return postOrder(cookie, model.OrderDTO{TicketID: ticketID}).Code
+	}
+	cookie := signIn(&common.UserPayload{ID: 14, Email: "scalper@gmail.com"})
+	unpaidTicketID := newTitledTicket(t, "ticket")
+	extraTicketID := newTitledTicket(t, "ticket")
+	paidTicketID := newTitledTicket(t, "festival")
+
+	t.Run("create more orders than allowed to be active", func(t *testing.T) {
+		*testLimits = config.Limits{MaxActiveOrders: 1}
+
+		assertResponseCode(t, http.StatusCreated, orderTicket(cookie, unpaidTicketID))
+		assertResponseCode(t, http.StatusConflict, orderTicket(cookie, extraTicketID))
+	})
+
+	t.Run("order more tickets with one title than allowed", func(t *testing.T) {
+		*testLimits = config.Limits{MaxOrdersPerTitle: 1}
+
+		assertResponseCode(t, http.StatusCreated, orderTicket(cookie, paidTicketID))
+		order, err := orderRepo.FindOneByTicketID(context.Background(), paidTicketID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := orderService.Complete(context.Background(), order.ID, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assertResponseCode(t, http.StatusConflict, orderTicket(cookie, newTitledTicket(t, "festival")))
+		// the unpaid order of the first ticket counts as well
+		assertResponseCode(t, http.StatusConflict, orderTicket(cookie, extraTicketID))
+	})
+
+	t.Run("rename an ordered ticket", func(t *testing.T) {
+		*testLimits = config.Limits{MaxOrdersPerTitle: 1}
+		renamedTicketID := newTitledTicket(t, "festival day two")
+		ticket, err := ticketRepo.FindOne(context.Background(), paidTicketID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ticket.Title = "festival day two"
+		if _, err := ticketRepo.Update(context.Background(), ticket); err != nil {
+			t.Fatal(err)
+		}
+
+		assertResponseCode(t, http.StatusConflict, orderTicket(cookie, renamedTicketID))
+	})
+
+	t.Run("order again right after letting orders expire", func(t *testing.T) {
+		*testLimits = config.Limits{Cooldown: config.Cooldown{Expirations: 1, Window: time.Hour, Duration: time.Hour}}
+		cookie := signIn(&common.UserPayload{ID: 15, Email: "sleepy@gmail.com"})
+
+		order := createOrder(t, cookie, model.OrderDTO{TicketID: newTitledTicket(t, "ticket")})
+		if _, err := orderService.Expire(context.Background(), order.ID, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assertResponseCode(t, http.StatusTooManyRequests, orderTicket(cookie, newTitledTicket(t, "ticket")))
+	})
+}
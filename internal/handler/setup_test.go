@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -17,11 +21,21 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/config"
 	"github.com/muktiarafi/ticketing-orders/internal/driver"
 	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/events/outbox"
+	"github.com/muktiarafi/ticketing-orders/internal/events/producer"
+	"github.com/muktiarafi/ticketing-orders/internal/health"
+	custommiddleware "github.com/muktiarafi/ticketing-orders/internal/middleware"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
 	"github.com/muktiarafi/ticketing-orders/internal/repository"
 	"github.com/muktiarafi/ticketing-orders/internal/service"
+	"github.com/muktiarafi/ticketing-orders/internal/statemachine"
+	"github.com/muktiarafi/ticketing-orders/internal/testutil"
 	"github.com/ory/dockertest/v3"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 var (
@@ -31,9 +45,28 @@ var (
 
 var router *echo.Echo
 var ticketRepo repository.TicketRepository
+var orderRepo repository.OrderRepository
+var transactor repository.Transactor
+var orderService service.OrderService
+var db *driver.DB
+
+var migrationFilePath = filepath.Join("..", "..", "db", "migrations")
+
+var testClock = fixedClock(time.Now().UTC().Truncate(time.Second))
+var testLimits = &config.Limits{}
+var testAdmins = &config.Admins{
+	Emails:  map[string]bool{"admin@gmail.com": true},
+	UserIDs: map[int64]bool{},
+}
+var testExpiration = &config.Expiration{
+	Default: time.Minute,
+	Tickets: map[int64]time.Duration{
+		23: 10 * time.Minute,
+	},
+}
 
 func TestMain(m *testing.M) {
-	db := &driver.DB{
+	db = &driver.DB{
 		SQL: newTestDatabase(),
 	}
 
@@ -41,19 +74,62 @@ func TestMain(m *testing.M) {
 	router.Use(middleware.Logger())
 
 	val := validator.New()
+	if err := money.RegisterValidations(val); err != nil {
+		log.Fatal(err)
+	}
 	trans := common.NewDefaultTranslator(val)
 	customValidator := &common.CustomValidator{val, trans}
 	router.Validator = customValidator
-	router.HTTPErrorHandler = common.CustomErrorHandler
+	router.HTTPErrorHandler = ErrorHandler
 
 	ticketRepo = repository.NewTicketRepository(db)
-	orderRepository := repository.NewOrderRepository(db)
+	orderRepo = repository.NewOrderRepository(db)
 
-	orderPublisher := &OrderPublisherStub{}
-	orderService := service.NewOrderService(orderRepository, ticketRepo, orderPublisher)
+	outboxPublisher := outbox.NewPublisher(repository.NewOutboxRepository(db))
+	orderPublisher := producer.NewOrderProducer(outboxPublisher)
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	transactor = repository.NewTransactor(db)
+	orderService = service.NewOrderService(
+		orderRepo,
+		ticketRepo,
+		repository.NewOrderEventRepository(db),
+		waitlistRepo,
+		repository.NewPaymentRepository(db),
+		orderPublisher,
+		producer.NewWaitlistProducer(outboxPublisher),
+		transactor,
+		statemachine.NewOrderMachine(),
+		service.NewOrderLimiter(orderRepo, testLimits, prometheus.NewCounterVec(
+			prometheus.CounterOpts{Name: "order_limit_rejections_total"},
+			[]string{"rule"},
+		)),
+		testExpiration,
+		testClock,
+	)
 
-	orderHandler := NewOrderHandler(orderService)
+	idempotency := custommiddleware.Idempotency(repository.NewIdempotencyRepository(db), time.Hour, time.Minute)
+	orderHandler := NewOrderHandler(orderService, idempotency)
 	orderHandler.Route(router)
+	waitlistHandler := NewWaitlistHandler(service.NewWaitlistService(waitlistRepo, ticketRepo, orderRepo, transactor, testClock))
+	waitlistHandler.Route(router)
+	adminService := service.NewAdminService(
+		orderService,
+		orderRepo,
+		ticketRepo,
+		repository.NewOrderEventRepository(db),
+		waitlistRepo,
+		repository.NewAdminActionRepository(db),
+		transactor,
+		testClock,
+	)
+	adminHandler := NewAdminHandler(adminService, custommiddleware.RequireAdmin(testAdmins))
+	adminHandler.Route(router)
+
+	checker := health.NewChecker(time.Second)
+	checker.Register("postgres", health.PostgresCheck(db.SQL))
+	checker.Register("migrations", health.MigrationCheck(db.SQL, migrationFilePath))
+	healthHandler := NewHealthHandler(checker)
+	healthHandler.Route(router)
 
 	code := m.Run()
 
@@ -85,7 +161,6 @@ func newTestDatabase() *sql.DB {
 			return err
 		}
 
-		migrationFilePath := filepath.Join("..", "..", "db", "migrations")
 		return driver.Migration(migrationFilePath, db)
 	}); err != nil {
 		log.Fatalf("Could not connect to docker: %s", err)
@@ -116,18 +191,74 @@ func signIn(userPayload *common.UserPayload) *http.Cookie {
 	return &cookie
 }
 
-type OrderPublisherStub struct{}
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func countOutboxMessages(t testing.TB, topic string) int {
+	t.Helper()
+
+	var count int
+	stmt := `SELECT COUNT(*) FROM outbox WHERE topic = $1`
+	if err := db.SQL.QueryRow(stmt, topic).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+// newTicket stores a ticket no other test orders.
+func newTicket(t testing.TB) *entity.Ticket {
+	t.Helper()
+
+	return testutil.InsertTicket(t, ticketRepo, "ticket", money.New(1200, "USD"))
+}
+
+func postOrder(cookie *http.Cookie, orderDTO model.OrderDTO) *httptest.ResponseRecorder {
+	orderDTOJSON, _ := json.Marshal(orderDTO)
+	request := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewBuffer(orderDTOJSON))
+	request.Header.Set("Content-Type", "application/json")
+	request.AddCookie(cookie)
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+// createOrder posts orderDTO and returns the order it created.
+func createOrder(t testing.TB, cookie *http.Cookie, orderDTO model.OrderDTO) *entity.Order {
+	t.Helper()
+
+	response := postOrder(cookie, orderDTO)
+	if response.Code != http.StatusCreated {
+		t.Fatalf("expecting order to be created but got status code %d instead", response.Code)
+	}
+
+	return decodeOrder(t, response)
+}
 
-func (p *OrderPublisherStub) Created(order *entity.Order) error {
-	fmt.Println("Order publisher publish order created event")
+// placeOrder orders a new ticket for the user behind cookie.
+func placeOrder(t testing.TB, cookie *http.Cookie) *entity.Order {
+	t.Helper()
 
-	return nil
+	return createOrder(t, cookie, model.OrderDTO{TicketID: newTicket(t).ID})
 }
 
-func (p *OrderPublisherStub) Cancelled(order *entity.Order) error {
-	fmt.Println("Order publisher publish order cancelled event")
+func decodeOrder(t testing.TB, response *httptest.ResponseRecorder) *entity.Order {
+	t.Helper()
+
+	responseBody, _ := ioutil.ReadAll(response.Body)
+	apiResponse := struct {
+		Data *entity.Order `json:"data"`
+	}{}
+	if err := json.Unmarshal(responseBody, &apiResponse); err != nil {
+		t.Fatal(err)
+	}
 
-	return nil
+	return apiResponse.Data
 }
 
 type TicketHelper struct {
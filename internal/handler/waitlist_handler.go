@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+)
+
+type WaitlistHandler struct {
+	service.WaitlistService
+}
+
+func NewWaitlistHandler(waitlistSrv service.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{
+		WaitlistService: waitlistSrv,
+	}
+}
+
+func (h *WaitlistHandler) Route(e *echo.Echo) {
+	waitlist := e.Group("/api/tickets/:ticketID/waitlist", common.RequireAuth)
+	waitlist.POST("", h.Join)
+	waitlist.GET("", h.Show)
+	waitlist.DELETE("", h.Leave)
+}
+
+func (h *WaitlistHandler) Join(c echo.Context) error {
+	const op = "WaitlistHandler.Join"
+	userID, ticketID, err := waitlistParams(c, op)
+	if err != nil {
+		return err
+	}
+
+	entry, err := h.WaitlistService.Join(c.Request().Context(), userID, ticketID)
+	if err != nil {
+		return err
+	}
+
+	return common.NewResponse(http.StatusCreated, "Created", entry).SendJSON(c)
+}
+
+func (h *WaitlistHandler) Show(c echo.Context) error {
+	const op = "WaitlistHandler.Show"
+	userID, ticketID, err := waitlistParams(c, op)
+	if err != nil {
+		return err
+	}
+
+	entry, err := h.WaitlistService.Show(c.Request().Context(), userID, ticketID)
+	if err != nil {
+		return err
+	}
+
+	return common.NewResponse(http.StatusOK, "OK", entry).SendJSON(c)
+}
+
+func (h *WaitlistHandler) Leave(c echo.Context) error {
+	const op = "WaitlistHandler.Leave"
+	userID, ticketID, err := waitlistParams(c, op)
+	if err != nil {
+		return err
+	}
+
+	if err := h.WaitlistService.Leave(c.Request().Context(), userID, ticketID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func waitlistParams(c echo.Context, op string) (int64, int64, error) {
+	userPayload, ok := c.Get("userPayload").(*common.UserPayload)
+	if !ok {
+		return 0, 0, &common.Error{
+			Op:  op,
+			Err: errors.New("missing payload in context"),
+		}
+	}
+
+	ticketID, err := strconv.ParseInt(c.Param("ticketID"), 10, 64)
+	if err != nil {
+		return 0, 0, &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid ticket Id",
+			Err:     err,
+		}
+	}
+
+	return int64(userPayload.ID), ticketID, nil
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+)
+
+func TestWaitlistHandler(t *testing.T) {
+	holderCookie := signIn(&common.UserPayload{ID: 12, Email: "holder@gmail.com"})
+	waiterCookie := signIn(&common.UserPayload{ID: 13, Email: "waiter@gmail.com"})
+
+	ticket := newTicket(t)
+	waitlistPath := fmt.Sprintf("/api/tickets/%d/waitlist", ticket.ID)
+
+	waitlistRequest := func(method string, cookie *http.Cookie) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, waitlistPath, nil)
+		request.AddCookie(cookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		return response
+	}
+
+	t.Run("join the waitlist of an available ticket", func(t *testing.T) {
+		response := waitlistRequest(http.MethodPost, waiterCookie)
+		assertResponseCode(t, http.StatusBadRequest, response.Code)
+	})
+
+	heldOrder := createOrder(t, holderCookie, model.OrderDTO{TicketID: ticket.ID})
+
+	t.Run("join the waitlist of a reserved ticket", func(t *testing.T) {
+		response := waitlistRequest(http.MethodPost, waiterCookie)
+		assertResponseCode(t, http.StatusCreated, response.Code)
+
+		responseBody, _ := ioutil.ReadAll(response.Body)
+		apiResponse := struct {
+			Data *entity.WaitlistEntry `json:"data"`
+		}{}
+		json.Unmarshal(responseBody, &apiResponse)
+
+		if apiResponse.Data.Position != 1 {
+			t.Errorf("expecting position to be 1 but got %d instead", apiResponse.Data.Position)
+		}
+
+		assertResponseCode(t, http.StatusConflict, waitlistRequest(http.MethodPost, waiterCookie).Code)
+	})
+
+	t.Run("join the waitlist of a ticket reserved by yourself", func(t *testing.T) {
+		response := waitlistRequest(http.MethodPost, holderCookie)
+		assertResponseCode(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("first waiting user gets the released ticket", func(t *testing.T) {
+		outboxCount := countOutboxMessages(t, schema.WaitlistPromoted)
+		cancelJSON, _ := json.Marshal(model.CancelOrderDTO{ReasonCode: "CHANGED_MIND"})
+
+		request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/orders/%d/cancel", heldOrder.ID), bytes.NewBuffer(cancelJSON))
+		request.Header.Set("Content-Type", "application/json")
+		request.AddCookie(holderCookie)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+		assertResponseCode(t, http.StatusOK, response.Code)
+
+		order, err := orderRepo.FindOneByTicketID(context.Background(), ticket.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.UserID != 13 || order.Status != "CREATED" {
+			t.Errorf("expecting a CREATED order for user 13 but got %q for user %d instead", order.Status, order.UserID)
+		}
+		if got := countOutboxMessages(t, schema.WaitlistPromoted); got != outboxCount+1 {
+			t.Errorf("expecting promoted event to be written to the outbox, got %d messages instead of %d", got, outboxCount+1)
+		}
+
+		assertResponseCode(t, http.StatusNotFound, waitlistRequest(http.MethodGet, waiterCookie).Code)
+	})
+
+	t.Run("leave the waitlist", func(t *testing.T) {
+		assertResponseCode(t, http.StatusCreated, waitlistRequest(http.MethodPost, holderCookie).Code)
+		assertResponseCode(t, http.StatusNoContent, waitlistRequest(http.MethodDelete, holderCookie).Code)
+		assertResponseCode(t, http.StatusNotFound, waitlistRequest(http.MethodGet, holderCookie).Code)
+	})
+}
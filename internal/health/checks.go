@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+)
+
+func PostgresCheck(db *sql.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// MigrationCheck fails while the schema is dirty or behind the newest
+// migration file in migrationFilePath.
+func MigrationCheck(db *sql.DB, migrationFilePath string) CheckFunc {
+	return func(ctx context.Context) error {
+		latest, err := driver.LatestMigrationVersion(migrationFilePath)
+		if err != nil {
+			return err
+		}
+
+		version, dirty, err := driver.MigrationVersion(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", version)
+		}
+		if version < latest {
+			return fmt.Errorf("schema is at version %d, expected %d", version, latest)
+		}
+
+		return nil
+	}
+}
+
+// KafkaCheck asks the brokers for the cluster metadata with the Kafka
+// client used by the publisher and subscriber, so a broker that accepts
+// connections but cannot serve clients is reported as down. When
+// consumerGroup is set the coordinator of the group must answer too, as the
+// subscriber cannot consume without it.
+func KafkaCheck(brokers []string, consumerGroup string) CheckFunc {
+	return func(ctx context.Context) error {
+		result := make(chan error, 1)
+		go func() {
+			result <- kafkaMetadata(ctx, brokers, consumerGroup)
+		}()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-result:
+			return err
+		}
+	}
+}
+
+func kafkaMetadata(ctx context.Context, brokers []string, consumerGroup string) error {
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V1_0_0_0
+	cfg.ClientID = "orders-health"
+	cfg.Metadata.Retry.Max = 0
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline)
+		cfg.Net.DialTimeout = timeout
+		cfg.Net.ReadTimeout = timeout
+		cfg.Net.WriteTimeout = timeout
+	}
+
+	client, err := sarama.NewClient(brokers, cfg)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if len(client.Brokers()) == 0 {
+		return errors.New("kafka returned no brokers")
+	}
+
+	if consumerGroup != "" {
+		if _, err := client.Coordinator(consumerGroup); err != nil {
+			return fmt.Errorf("coordinator of %s: %w", consumerGroup, err)
+		}
+	}
+
+	return nil
+}
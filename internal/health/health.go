@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+type CheckFunc func(ctx context.Context) error
+
+type CheckResult struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string         `json:"status"`
+	Checks []*CheckResult `json:"checks"`
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker runs every registered dependency check concurrently, each bounded
+// by timeout.
+type Checker struct {
+	checks  []check
+	timeout time.Duration
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+	}
+}
+
+func (c *Checker) Register(name string, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+func (c *Checker) Run(ctx context.Context) *Report {
+	report := &Report{
+		Status: StatusUp,
+		Checks: make([]*CheckResult, len(c.checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, ch := range c.checks {
+		wg.Add(1)
+		go func(i int, ch check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			result := &CheckResult{Name: ch.name, Status: StatusUp}
+			if err := ch.fn(ctx); err != nil {
+				result.Status = StatusDown
+				result.Error = err.Error()
+			}
+			result.LatencyMs = time.Since(start).Milliseconds()
+			report.Checks[i] = result
+		}(i, ch)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/config"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+)
+
+// RequireAdmin lets only admins through. It must run after
+// common.RequireAuth.
+func RequireAdmin(admins *config.Admins) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			const op = "RequireAdmin"
+			userPayload, ok := c.Get("userPayload").(*common.UserPayload)
+			if !ok {
+				return &common.Error{
+					Op:  op,
+					Err: errors.New("missing payload in context"),
+				}
+			}
+
+			if !admins.Has(int64(userPayload.ID), userPayload.Email) {
+				return &common.Error{
+					Code:    constant.EFORBIDDEN,
+					Op:      op,
+					Message: "Admin access required",
+					Err:     fmt.Errorf("user %d is not an admin", userPayload.ID),
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
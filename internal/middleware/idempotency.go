@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyCleanupTimeout = 3 * time.Second
+)
+
+// Idempotency replays the stored response when a user repeats a request with
+// the same Idempotency-Key within window. Only successful responses are
+// stored, so a failed request can be retried with the same key. A request
+// that never completes, e.g. because the process crashed, holds the key for
+// lease at most. Reusing a key with a different request is rejected with 422.
+// It must run after common.RequireAuth since keys are scoped per user.
+func Idempotency(idempotencyRepo repository.IdempotencyRepository, window, lease time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			const op = "Idempotency"
+			key := c.Request().Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				return next(c)
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				return &common.Error{
+					Code:    common.EINVALID,
+					Op:      op,
+					Message: "Idempotency-Key is too long",
+					Err:     errors.New("idempotency key exceeds maximum length"),
+				}
+			}
+
+			userPayload, ok := c.Get("userPayload").(*common.UserPayload)
+			if !ok {
+				return &common.Error{
+					Op:  op,
+					Err: errors.New("missing payload in context"),
+				}
+			}
+
+			body, err := ioutil.ReadAll(c.Request().Body)
+			if err != nil {
+				return &common.Error{Op: op, Err: err}
+			}
+			c.Request().Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			idempotencyKey := &entity.IdempotencyKey{
+				UserID:      int64(userPayload.ID),
+				Key:         key,
+				RequestHash: hashRequest(c, body),
+			}
+
+			reserved, err := idempotencyRepo.Reserve(c.Request().Context(), idempotencyKey, window, lease)
+			if err != nil {
+				return err
+			}
+			if !reserved {
+				return replay(c, idempotencyRepo, idempotencyKey)
+			}
+
+			recorder := &bodyRecorder{ResponseWriter: c.Response().Writer, body: new(bytes.Buffer)}
+			c.Response().Writer = recorder
+
+			// the request context may already be cancelled at this point, but
+			// the key still has to be released or completed
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), idempotencyCleanupTimeout)
+			defer cancel()
+			release := func() {
+				if err := idempotencyRepo.Delete(cleanupCtx, idempotencyKey.UserID, idempotencyKey.Key); err != nil {
+					log.Printf("could not release idempotency key: %v", err)
+				}
+			}
+			defer func() {
+				if r := recover(); r != nil {
+					release()
+					panic(r)
+				}
+			}()
+
+			err = next(c)
+			status := c.Response().Status
+			if err != nil || status < http.StatusOK || status >= http.StatusMultipleChoices {
+				release()
+				return err
+			}
+
+			idempotencyKey.StatusCode = status
+			idempotencyKey.ResponseBody = recorder.body.Bytes()
+			if err := idempotencyRepo.SaveResponse(cleanupCtx, idempotencyKey); err != nil {
+				log.Printf("could not store idempotent response: %v", err)
+			}
+
+			return nil
+		}
+	}
+}
+
+func replay(c echo.Context, idempotencyRepo repository.IdempotencyRepository, idempotencyKey *entity.IdempotencyKey) error {
+	const op = "Idempotency.replay"
+	stored, err := idempotencyRepo.FindOne(c.Request().Context(), idempotencyKey.UserID, idempotencyKey.Key)
+	if err != nil && common.ErrorCode(err) != common.ENOTFOUND {
+		return err
+	}
+
+	if stored != nil && stored.RequestHash != idempotencyKey.RequestHash {
+		return &common.Error{
+			Code:    constant.EKEYREUSED,
+			Op:      op,
+			Message: "Idempotency-Key has already been used with a different request",
+			Err:     errors.New("idempotency key reused with different payload"),
+		}
+	}
+
+	if stored == nil || stored.StatusCode == 0 {
+		return &common.Error{
+			Code:    common.ECONCLICT,
+			Op:      op,
+			Message: "A request with this Idempotency-Key is still being processed",
+			Err:     errors.New("idempotency key is in use"),
+		}
+	}
+
+	c.Response().Header().Set(IdempotentReplayedHeader, "true")
+	return c.Blob(stored.StatusCode, echo.MIMEApplicationJSONCharsetUTF8, stored.ResponseBody)
+}
+
+func hashRequest(c echo.Context, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.Request().Method))
+	h.Write([]byte(c.Path()))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type bodyRecorder struct {
+	http.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,7 @@
+package model
+
+// AdminActionDTO carries the justification admins give for changing an
+// order. It is kept in the order history and the audit trail.
+type AdminActionDTO struct {
+	Note string `json:"note" validate:"required,max=500"`
+}
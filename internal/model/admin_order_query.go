@@ -0,0 +1,8 @@
+package model
+
+// AdminOrderQuery searches orders across users. UserID narrows the search to
+// one user.
+type AdminOrderQuery struct {
+	OrderQuery
+	UserID int64 `query:"userId" validate:"omitempty,min=1"`
+}
@@ -0,0 +1,6 @@
+package model
+
+type CancelOrderDTO struct {
+	ReasonCode string `json:"reasonCode" validate:"required,oneof=CHANGED_MIND ORDERED_BY_MISTAKE FOUND_BETTER_PRICE OTHER"`
+	Note       string `json:"note" validate:"max=500"`
+}
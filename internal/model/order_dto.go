@@ -1,5 +1,34 @@
 package model
 
 type OrderDTO struct {
+	// TicketID orders a single ticket. It predates Items and is kept for
+	// existing clients.
+	TicketID int64           `json:"ticketId" validate:"required_without=Items"`
+	Items    []*OrderItemDTO `json:"items" validate:"required_without=TicketID,omitempty,min=1,max=10,dive,required"`
+	// Currency, when given, is the currency the client expects to pay in.
+	Currency string `json:"currency" validate:"omitempty,currency"`
+}
+
+// OrderItemDTO is one requested ticket. A ticket is a single seat, so the
+// only quantity accepted for now is 1.
+type OrderItemDTO struct {
 	TicketID int64 `json:"ticketId" validate:"required"`
+	Quantity int   `json:"quantity" validate:"omitempty,min=1,max=1"`
+}
+
+// LineItems merges TicketID into Items and defaults missing quantities.
+func (dto *OrderDTO) LineItems() []*OrderItemDTO {
+	items := make([]*OrderItemDTO, 0, len(dto.Items)+1)
+	if dto.TicketID != 0 {
+		items = append(items, &OrderItemDTO{TicketID: dto.TicketID})
+	}
+	items = append(items, dto.Items...)
+
+	for _, item := range items {
+		if item.Quantity == 0 {
+			item.Quantity = 1
+		}
+	}
+
+	return items
 }
@@ -0,0 +1,9 @@
+package model
+
+// OrderPatchDTO lists the order fields a user may change. Fields left out of
+// the request are not touched. Status can only be set to CANCELLED, the other
+// statuses follow from payments and expiration.
+type OrderPatchDTO struct {
+	Status       *string         `json:"status" validate:"required_with=Cancellation,omitempty,oneof=CANCELLED"`
+	Cancellation *CancelOrderDTO `json:"cancellation" validate:"required_with=Status"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type OrderQuery struct {
+	Limit       int       `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor      string    `query:"cursor"`
+	Status      []string  `query:"status" validate:"dive,oneof=CREATED PENDING COMPLETED CANCELLED REFUND_REQUESTED REFUNDED"`
+	TicketID    int64     `query:"ticketId" validate:"omitempty,min=1"`
+	CreatedFrom time.Time `query:"createdFrom"`
+	CreatedTo   time.Time `query:"createdTo"`
+	ExpiresFrom time.Time `query:"expiresFrom"`
+	ExpiresTo   time.Time `query:"expiresTo"`
+	Sort        string    `query:"sort" validate:"omitempty,oneof=id -id createdAt -createdAt expiresAt -expiresAt"`
+}
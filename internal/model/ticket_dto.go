@@ -1,6 +1,8 @@
 package model
 
+import "github.com/muktiarafi/ticketing-orders/internal/money"
+
 type TicketDTO struct {
-	Title string  `json:"title" validate:"required,min=4"`
-	Price float64 `json:"price" validate:"required,gt=0"`
+	Title string      `json:"title" validate:"required,min=4"`
+	Price money.Money `json:"price" validate:"gt=0"`
 }
@@ -0,0 +1,154 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrCurrencyMismatch = errors.New("money: currency mismatch")
+
+// exponents holds the number of minor units of the supported ISO 4217
+// currencies.
+var exponents = map[string]int{
+	"AUD": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"IDR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"MYR": 2,
+	"SGD": 2,
+	"USD": 2,
+}
+
+// Money is an exact amount in the minor unit of its currency, e.g. cents for
+// USD.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func IsCurrency(code string) bool {
+	_, ok := exponents[code]
+	return ok
+}
+
+// Parse reads a decimal string such as "12.50". It fails when the value has
+// more fraction digits than the currency allows instead of rounding.
+func Parse(value, currency string) (Money, error) {
+	exponent, ok := exponents[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("money: unknown currency %q", currency)
+	}
+
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+	units, fraction := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		units, fraction = value[:i], value[i+1:]
+	}
+	if units == "" || len(fraction) > exponent || strings.ContainsAny(units+fraction, "+-") {
+		return Money{}, fmt.Errorf("money: invalid amount %q for %s", value, currency)
+	}
+
+	amount, err := strconv.ParseInt(units+fraction+strings.Repeat("0", exponent-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("money: invalid amount %q for %s", value, currency)
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return New(amount, currency), nil
+}
+
+// FromFloat converts prices received from other services, which are still
+// float64, rounding to the nearest minor unit.
+func FromFloat(value float64, currency string) (Money, error) {
+	exponent, ok := exponents[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("money: unknown currency %q", currency)
+	}
+
+	return New(int64(math.Round(value*math.Pow10(exponent))), currency), nil
+}
+
+// Float64 is only meant for events shared with other services.
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / math.Pow10(exponents[m.Currency])
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	return New(m.Amount+other.Amount, m.Currency), nil
+}
+
+func (m Money) Mul(n int64) Money {
+	return New(m.Amount*n, m.Currency)
+}
+
+// Decimal formats the amount in major units, e.g. "12.50".
+func (m Money) Decimal() string {
+	exponent := exponents[m.Currency]
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	digits := fmt.Sprintf("%0*d", exponent+1, amount)
+	if exponent == 0 {
+		return sign + digits
+	}
+
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+type jsonMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON writes the amount as a decimal string so clients do not lose
+// precision parsing it as a float.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.Decimal(), Currency: m.Currency})
+}
+
+func (m *Money) UnmarshalJSON(b []byte) error {
+	// amounts are accepted both as strings and as JSON numbers
+	var value struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency string          `json:"currency"`
+	}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return err
+	}
+
+	amount := strings.Trim(string(value.Amount), `"`)
+	parsed, err := Parse(amount, strings.ToUpper(value.Currency))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package money
+
+import (
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidations adds the "currency" tag for ISO 4217 codes and lets
+// number tags such as gt=0 check the amount of a Money field. A Money field
+// with an unsupported currency fails any tag.
+func RegisterValidations(v *validator.Validate) error {
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		m := field.Interface().(Money)
+		if !IsCurrency(m.Currency) {
+			return nil
+		}
+
+		return m.Amount
+	}, Money{})
+
+	return v.RegisterValidation("currency", func(fl validator.FieldLevel) bool {
+		return IsCurrency(fl.Field().String())
+	})
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type AdminActionRepository interface {
+	Insert(ctx context.Context, action *entity.AdminAction) (*entity.AdminAction, error)
+	Find(ctx context.Context, orderID int64) ([]*entity.AdminAction, error)
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type AdminActionRepositoryImpl struct {
+	*driver.DB
+}
+
+func NewAdminActionRepository(db *driver.DB) AdminActionRepository {
+	return &AdminActionRepositoryImpl{
+		DB: db,
+	}
+}
+
+func (r *AdminActionRepositoryImpl) Insert(ctx context.Context, action *entity.AdminAction) (*entity.AdminAction, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `INSERT INTO admin_actions (admin_id, admin_email, action, order_id, ticket_id, details, created_at)
+	VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5, 0), $6, $7)
+	RETURNING id`
+
+	newAction := *action
+	if err := conn(ctx, r.SQL).QueryRowContext(
+		ctx,
+		stmt,
+		action.AdminID,
+		action.AdminEmail,
+		action.Action,
+		action.OrderID,
+		action.TicketID,
+		action.Details,
+		action.CreatedAt,
+	).Scan(&newAction.ID); err != nil {
+		return nil, &common.Error{Op: "AdminActionRepository.Insert", Err: err}
+	}
+
+	return &newAction, nil
+}
+
+// Find returns the admin actions taken on an order, oldest first.
+func (r *AdminActionRepositoryImpl) Find(ctx context.Context, orderID int64) ([]*entity.AdminAction, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT id, admin_id, admin_email, action, order_id, ticket_id, details, created_at
+	FROM admin_actions
+	WHERE order_id = $1
+	ORDER BY id`
+
+	rows, err := conn(ctx, r.SQL).QueryContext(ctx, stmt, orderID)
+	if err != nil {
+		return nil, &common.Error{Op: "AdminActionRepository.Find", Err: err}
+	}
+	defer rows.Close()
+
+	actions := make([]*entity.AdminAction, 0)
+	for rows.Next() {
+		action := new(entity.AdminAction)
+		var actionOrderID, ticketID sql.NullInt64
+		if err := rows.Scan(
+			&action.ID,
+			&action.AdminID,
+			&action.AdminEmail,
+			&action.Action,
+			&actionOrderID,
+			&ticketID,
+			&action.Details,
+			&action.CreatedAt,
+		); err != nil {
+			return nil, &common.Error{Op: "AdminActionRepository.Find", Err: err}
+		}
+		action.OrderID = actionOrderID.Int64
+		action.TicketID = ticketID.Int64
+
+		actions = append(actions, action)
+	}
+
+	return actions, rows.Err()
+}
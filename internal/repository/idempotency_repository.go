@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, key *entity.IdempotencyKey, window, lease time.Duration) (bool, error)
+	FindOne(ctx context.Context, userID int64, key string) (*entity.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, key *entity.IdempotencyKey) error
+	Delete(ctx context.Context, userID int64, key string) error
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type IdempotencyRepositoryImpl struct {
+	*driver.DB
+}
+
+func NewIdempotencyRepository(db *driver.DB) IdempotencyRepository {
+	return &IdempotencyRepositoryImpl{
+		DB: db,
+	}
+}
+
+// Reserve claims the key for the current request. It reports false when the
+// key holds the response of a request made within window, or is reserved by
+// a request still within its lease.
+func (r *IdempotencyRepositoryImpl) Reserve(ctx context.Context, key *entity.IdempotencyKey, window, lease time.Duration) (bool, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `INSERT INTO idempotency_keys (user_id, key, request_hash)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id, key) DO UPDATE
+	SET request_hash = EXCLUDED.request_hash, status_code = NULL, response_body = NULL, created_at = NOW()
+	WHERE idempotency_keys.created_at < NOW() - $4 * INTERVAL '1 second'
+	OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < NOW() - $5 * INTERVAL '1 second')`
+
+	result, err := conn(ctx, r.SQL).ExecContext(ctx, stmt, key.UserID, key.Key, key.RequestHash, window.Seconds(), lease.Seconds())
+	if err != nil {
+		return false, &common.Error{Op: "IdempotencyRepository.Reserve", Err: err}
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, &common.Error{Op: "IdempotencyRepository.Reserve", Err: err}
+	}
+
+	return affected == 1, nil
+}
+
+func (r *IdempotencyRepositoryImpl) FindOne(ctx context.Context, userID int64, key string) (*entity.IdempotencyKey, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT user_id, key, request_hash, COALESCE(status_code, 0), response_body, created_at
+	FROM idempotency_keys
+	WHERE user_id = $1 AND key = $2`
+
+	idempotencyKey := new(entity.IdempotencyKey)
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, userID, key).Scan(
+		&idempotencyKey.UserID,
+		&idempotencyKey.Key,
+		&idempotencyKey.RequestHash,
+		&idempotencyKey.StatusCode,
+		&idempotencyKey.ResponseBody,
+		&idempotencyKey.CreatedAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{
+				Code:    common.ENOTFOUND,
+				Op:      "IdempotencyRepository.FindOne",
+				Message: "Idempotency Key Not Found",
+				Err:     err,
+			}
+		}
+		return nil, &common.Error{Op: "IdempotencyRepository.FindOne", Err: err}
+	}
+
+	return idempotencyKey, nil
+}
+
+func (r *IdempotencyRepositoryImpl) SaveResponse(ctx context.Context, key *entity.IdempotencyKey) error {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `UPDATE idempotency_keys
+	SET status_code = $1, response_body = $2
+	WHERE user_id = $3 AND key = $4`
+
+	if _, err := conn(ctx, r.SQL).ExecContext(
+		ctx,
+		stmt,
+		key.StatusCode,
+		key.ResponseBody,
+		key.UserID,
+		key.Key,
+	); err != nil {
+		return &common.Error{Op: "IdempotencyRepository.SaveResponse", Err: err}
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepositoryImpl) Delete(ctx context.Context, userID int64, key string) error {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `DELETE FROM idempotency_keys
+	WHERE user_id = $1 AND key = $2`
+
+	if _, err := conn(ctx, r.SQL).ExecContext(ctx, stmt, userID, key); err != nil {
+		return &common.Error{Op: "IdempotencyRepository.Delete", Err: err}
+	}
+
+	return nil
+}
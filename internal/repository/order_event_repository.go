@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type OrderEventRepository interface {
+	Insert(ctx context.Context, event *entity.OrderEvent) (*entity.OrderEvent, error)
+	Find(ctx context.Context, orderID int64) ([]*entity.OrderEvent, error)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type OrderEventRepositoryImpl struct {
+	*driver.DB
+}
+
+func NewOrderEventRepository(db *driver.DB) OrderEventRepository {
+	return &OrderEventRepositoryImpl{
+		DB: db,
+	}
+}
+
+func (r *OrderEventRepositoryImpl) Insert(ctx context.Context, event *entity.OrderEvent) (*entity.OrderEvent, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `INSERT INTO order_events (order_id, from_status, to_status, actor_type, actor_id, reason, source_event_id, created_at)
+	VALUES ($1, NULLIF($2, ''), $3, $4, NULLIF($5, 0), $6, NULLIF($7, ''), $8)
+	RETURNING id`
+
+	newEvent := *event
+	if err := conn(ctx, r.SQL).QueryRowContext(
+		ctx,
+		stmt,
+		event.OrderID,
+		event.FromStatus,
+		event.ToStatus,
+		event.ActorType,
+		event.ActorID,
+		event.Reason,
+		event.SourceEventID,
+		event.CreatedAt,
+	).Scan(&newEvent.ID); err != nil {
+		return nil, &common.Error{Op: "OrderEventRepository.Insert", Err: err}
+	}
+
+	return &newEvent, nil
+}
+
+// Find returns the history of an order, oldest entry first.
+func (r *OrderEventRepositoryImpl) Find(ctx context.Context, orderID int64) ([]*entity.OrderEvent, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT id, order_id, from_status, to_status, actor_type, actor_id, reason, source_event_id, created_at
+	FROM order_events
+	WHERE order_id = $1
+	ORDER BY id`
+
+	rows, err := conn(ctx, r.SQL).QueryContext(ctx, stmt, orderID)
+	if err != nil {
+		return nil, &common.Error{Op: "OrderEventRepository.Find", Err: err}
+	}
+	defer rows.Close()
+
+	events := make([]*entity.OrderEvent, 0)
+	for rows.Next() {
+		event := new(entity.OrderEvent)
+		var fromStatus, sourceEventID sql.NullString
+		var actorID sql.NullInt64
+		if err := rows.Scan(
+			&event.ID,
+			&event.OrderID,
+			&fromStatus,
+			&event.ToStatus,
+			&event.ActorType,
+			&actorID,
+			&event.Reason,
+			&sourceEventID,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, &common.Error{Op: "OrderEventRepository.Find", Err: err}
+		}
+		event.FromStatus = fromStatus.String
+		event.ActorID = actorID.Int64
+		event.SourceEventID = sourceEventID.String
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
@@ -1,12 +1,75 @@
 package repository
 
-import "github.com/muktiarafi/ticketing-orders/internal/entity"
+import (
+	"context"
+	"errors"
+	"time"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+// ErrVersionConflict is the cause of the error returned when an order changed
+// since it was read.
+var ErrVersionConflict = errors.New("order version is out of sync")
+
+// IsVersionConflict tells a lost update apart from other conflicts, such as a
+// forbidden status transition, which are not worth retrying.
+func IsVersionConflict(err error) bool {
+	for err != nil {
+		if err == ErrVersionConflict {
+			return true
+		}
+		e, ok := err.(*common.Error)
+		if !ok {
+			return false
+		}
+		err = e.Err
+	}
+
+	return false
+}
 
 type OrderRepository interface {
-	Insert(order *entity.Order) (*entity.Order, error)
-	Find(userID int64) ([]*entity.Order, error)
-	FindReserved(userID int64) ([]*entity.Order, error)
-	FindOne(orderID int64) (*entity.Order, error)
-	FindOneByTicketID(ticketID int64) (*entity.Order, error)
-	Update(order *entity.Order) (*entity.Order, error)
+	Insert(ctx context.Context, order *entity.Order) (*entity.Order, error)
+	Find(ctx context.Context, filter *OrderFilter) ([]*entity.Order, error)
+	FindReserved(ctx context.Context, ticketID int64) ([]*entity.Order, error)
+	FindOne(ctx context.Context, orderID int64) (*entity.Order, error)
+	FindOneByTicketID(ctx context.Context, ticketID int64) (*entity.Order, error)
+	Update(ctx context.Context, order *entity.Order) (*entity.Order, error)
+	FindExpired(ctx context.Context, before time.Time, skip []int64, limit int) ([]int64, error)
+	LockUser(ctx context.Context, userID int64) error
+	CountActive(ctx context.Context, userID int64) (int, error)
+	CountByTitle(ctx context.Context, userID int64, title string) (int, error)
+	CountExpired(ctx context.Context, userID int64, since time.Time) (int, time.Time, error)
+}
+
+const (
+	OrderSortID        = "id"
+	OrderSortCreatedAt = "created_at"
+	OrderSortExpiresAt = "expires_at"
+)
+
+// OrderFilter narrows Find. Zero values are ignored, so a zero UserID
+// searches the orders of every user. Results are ordered by SortBy then id,
+// and After resumes right behind the last order of the previous page.
+type OrderFilter struct {
+	UserID      int64
+	Statuses    []string
+	TicketID    int64
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	ExpiresFrom time.Time
+	ExpiresTo   time.Time
+	SortBy      string
+	Descending  bool
+	After       *OrderCursor
+	Limit       int
+}
+
+// OrderCursor is the position of an order within a sort order. SortValue is
+// unused when sorting by id.
+type OrderCursor struct {
+	SortValue time.Time `json:"v,omitempty"`
+	ID        int64     `json:"id"`
 }
@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	common "github.com/muktiarafi/ticketing-common"
 	"github.com/muktiarafi/ticketing-orders/internal/driver"
@@ -18,268 +22,504 @@ func NewOrderRepository(db *driver.DB) OrderRepository {
 	}
 }
 
-func (r *OrderRepositoryImpl) Insert(order *entity.Order) (*entity.Order, error) {
-	ctx, cancel := newDBContext()
+// Insert stores the order together with its items. Callers should run it
+// inside a transaction so that a failing item does not leave a partial order.
+func (r *OrderRepositoryImpl) Insert(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
 	defer cancel()
 
-	stmt := `INSERT INTO orders (status, expires_at, user_id, ticket_id)
-	VALUES ($1, $2, $3, $4)
-	RETURNING *`
+	stmt := `INSERT INTO orders (status, expires_at, created_at, user_id, total, currency)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, status, expires_at, created_at, user_id, total, currency, version`
 
 	newOrder := new(entity.Order)
-	var ticketID int64
-	if err := r.SQL.QueryRowContext(
+	if err := conn(ctx, r.SQL).QueryRowContext(
 		ctx,
 		stmt,
 		order.Status,
 		order.ExpiresAt,
+		order.CreatedAt,
 		order.UserID,
-		order.Ticket.ID,
+		order.Total.Amount,
+		order.Total.Currency,
 	).Scan(
 		&newOrder.ID,
 		&newOrder.Status,
 		&newOrder.ExpiresAt,
+		&newOrder.CreatedAt,
 		&newOrder.UserID,
-		&ticketID,
+		&newOrder.Total.Amount,
+		&newOrder.Total.Currency,
 		&newOrder.Version,
 	); err != nil {
 		return nil, &common.Error{Op: "OrderRepository.Insert", Err: err}
 	}
 
-	ticketStmt := `SELECT * FROM tickets
-	WHERE id = $1`
-
-	ticket := new(entity.Ticket)
-	if err := r.SQL.QueryRowContext(ctx, ticketStmt, ticketID).Scan(
-		&ticket.ID,
-		&ticket.Title,
-		&ticket.Price,
-		&ticket.Version,
-	); err != nil {
-		return nil, &common.Error{Op: "OrderRepository.Insert", Err: err}
+	itemStmt := `INSERT INTO order_items (order_id, ticket_id, title, quantity, unit_price, currency)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id`
+
+	newOrder.Items = make([]*entity.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		newItem := &entity.OrderItem{
+			OrderID:   newOrder.ID,
+			Title:     item.Title,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Ticket:    item.Ticket,
+		}
+		if err := conn(ctx, r.SQL).QueryRowContext(
+			ctx,
+			itemStmt,
+			newOrder.ID,
+			item.Ticket.ID,
+			item.Title,
+			item.Quantity,
+			item.UnitPrice.Amount,
+			item.UnitPrice.Currency,
+		).Scan(&newItem.ID); err != nil {
+			return nil, &common.Error{Op: "OrderRepository.Insert", Err: err}
+		}
+		newOrder.Items = append(newOrder.Items, newItem)
 	}
 
-	newOrder.Ticket = ticket
-
 	return newOrder, nil
 }
 
-func (r *OrderRepositoryImpl) Find(userID int64) ([]*entity.Order, error) {
-	ctx, cancel := newDBContext()
+func (r *OrderRepositoryImpl) Find(ctx context.Context, filter *OrderFilter) ([]*entity.Order, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
 	defer cancel()
 
-	stmt := `SELECT o.id, status, expires_at, user_id, o.version, t.id, title, price, t.version
-	FROM orders AS o JOIN tickets AS t
-	ON o.ticket_id = t.id
-	WHERE o.user_id = $1
-	ORDER BY o.id`
+	var args []interface{}
+	where := []string{"TRUE"}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(condition, len(args)))
+	}
 
-	rows, err := r.SQL.QueryContext(ctx, stmt, userID)
-	if err != nil {
-		return nil, &common.Error{Op: "OrderRepository.Find", Err: err}
+	if filter.UserID != 0 {
+		addCondition("o.user_id = $%d", filter.UserID)
 	}
-	defer rows.Close()
 
-	orders := make([]*entity.Order, 0)
-	for rows.Next() {
-		order := new(entity.Order)
-		ticket := new(entity.Ticket)
+	if len(filter.Statuses) != 0 {
+		addCondition("status = ANY($%d)", filter.Statuses)
+	}
+	if filter.TicketID != 0 {
+		addCondition("EXISTS (SELECT 1 FROM order_items AS i WHERE i.order_id = o.id AND i.ticket_id = $%d)", filter.TicketID)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		addCondition("o.created_at >= $%d", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		addCondition("o.created_at < $%d", filter.CreatedTo)
+	}
+	if !filter.ExpiresFrom.IsZero() {
+		addCondition("expires_at >= $%d", filter.ExpiresFrom)
+	}
+	if !filter.ExpiresTo.IsZero() {
+		addCondition("expires_at < $%d", filter.ExpiresTo)
+	}
 
-		if err := rows.Scan(
-			&order.ID,
-			&order.Status,
-			&order.ExpiresAt,
-			&order.UserID,
-			&order.Version,
-			&ticket.ID,
-			&ticket.Title,
-			&ticket.Price,
-			&ticket.Version,
-		); err != nil {
-			return nil, &common.Error{Op: "OrderRepository.Find", Err: err}
+	sortColumn := "o.id"
+	switch filter.SortBy {
+	case OrderSortCreatedAt:
+		sortColumn = "o.created_at"
+	case OrderSortExpiresAt:
+		sortColumn = "o.expires_at"
+	}
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		if sortColumn == "o.id" {
+			addCondition("o.id "+comparison+" $%d", filter.After.ID)
+		} else {
+			args = append(args, filter.After.SortValue, filter.After.ID)
+			where = append(where, fmt.Sprintf(
+				"(%s, o.id) %s ($%d, $%d)",
+				sortColumn,
+				comparison,
+				len(args)-1,
+				len(args),
+			))
 		}
-		order.Ticket = ticket
+	}
 
-		orders = append(orders, order)
+	orderBy := fmt.Sprintf("%s %s", sortColumn, direction)
+	if sortColumn != "o.id" {
+		orderBy += fmt.Sprintf(", o.id %s", direction)
+	}
+
+	limit := ""
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		limit = fmt.Sprintf("LIMIT $%d", len(args))
+	}
+
+	stmt := fmt.Sprintf(`SELECT o.id, status, expires_at, o.created_at, user_id, o.total, o.currency, o.version, o.cancellation_reason, o.cancellation_note
+	FROM orders AS o
+	WHERE %s
+	ORDER BY %s
+	%s`, strings.Join(where, " AND "), orderBy, limit)
+
+	rows, err := conn(ctx, r.SQL).QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, &common.Error{Op: "OrderRepository.Find", Err: err}
+	}
+
+	orders, err := scanOrders(rows)
+	if err != nil {
+		return nil, &common.Error{Op: "OrderRepository.Find", Err: err}
+	}
+
+	if err := r.loadItems(ctx, orders); err != nil {
+		return nil, &common.Error{Op: "OrderRepository.Find", Err: err}
 	}
 
 	return orders, nil
 }
 
-func (r *OrderRepositoryImpl) FindReserved(ticketID int64) ([]*entity.Order, error) {
-	ctx, cancel := newDBContext()
+// FindReserved returns the orders currently holding the ticket.
+func (r *OrderRepositoryImpl) FindReserved(ctx context.Context, ticketID int64) ([]*entity.Order, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
 	defer cancel()
 
-	stmt := `SELECT o.id, status, expires_at, user_id, o.version, t.id, title, price, t.version
-	FROM orders AS o JOIN tickets AS t
-	ON o.ticket_id = t.id
-	WHERE t.id = $1 AND status IN ('CREATED', 'PENDING', 'COMPLETED')`
+	stmt := `SELECT o.id, status, expires_at, o.created_at, user_id, o.total, o.currency, o.version, o.cancellation_reason, o.cancellation_note
+	FROM orders AS o JOIN order_items AS i
+	ON i.order_id = o.id
+	WHERE i.ticket_id = $1 AND status IN ('CREATED', 'PENDING', 'COMPLETED', 'REFUND_REQUESTED')`
 
-	rows, err := r.SQL.QueryContext(ctx, stmt, ticketID)
+	rows, err := conn(ctx, r.SQL).QueryContext(ctx, stmt, ticketID)
 	if err != nil {
 		return nil, &common.Error{Op: "OrderRepositoryImpl.FindReserved", Err: err}
 	}
-	defer rows.Close()
 
-	orders := make([]*entity.Order, 0)
-	for rows.Next() {
-		order := new(entity.Order)
-		ticket := new(entity.Ticket)
+	orders, err := scanOrders(rows)
+	if err != nil {
+		return nil, &common.Error{Op: "OrderRepositoryImpl.FindReserved", Err: err}
+	}
 
-		if err := rows.Scan(
-			&order.ID,
-			&order.Status,
-			&order.ExpiresAt,
-			&order.UserID,
-			&order.Version,
-			&ticket.ID,
-			&ticket.Title,
-			&ticket.Price,
-			&ticket.Version,
-		); err != nil {
-			return nil, &common.Error{Op: "OrderRepositoryImpl.FindReserved", Err: err}
-		}
-		order.Ticket = ticket
-		orders = append(orders, order)
+	if err := r.loadItems(ctx, orders); err != nil {
+		return nil, &common.Error{Op: "OrderRepositoryImpl.FindReserved", Err: err}
 	}
 
 	return orders, nil
 }
 
-func (r *OrderRepositoryImpl) FindOne(orderID int64) (*entity.Order, error) {
-	ctx, cancel := newDBContext()
+func (r *OrderRepositoryImpl) FindOne(ctx context.Context, orderID int64) (*entity.Order, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT id, status, expires_at, created_at, user_id, total, currency, version, cancellation_reason, cancellation_note
+	FROM orders
+	WHERE id = $1`
+
+	return r.findOne(ctx, "OrderRepository.FindOne", stmt, orderID)
+}
+
+// FindOneByTicketID returns the most recent order containing the ticket.
+func (r *OrderRepositoryImpl) FindOneByTicketID(ctx context.Context, ticketID int64) (*entity.Order, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
 	defer cancel()
 
-	stmt := `SELECT o.id, status, expires_at, user_id, o.version, t.id, title, price, t.version
-	FROM orders AS o JOIN tickets AS t
-	ON o.ticket_id = t.id
-	WHERE o.id = $1`
+	stmt := `SELECT o.id, status, expires_at, o.created_at, user_id, o.total, o.currency, o.version, o.cancellation_reason, o.cancellation_note
+	FROM orders AS o JOIN order_items AS i
+	ON i.order_id = o.id
+	WHERE i.ticket_id = $1
+	ORDER BY o.id DESC
+	LIMIT 1`
+
+	return r.findOne(ctx, "OrderRepository.FindOneByTicketID", stmt, ticketID)
+}
 
+func (r *OrderRepositoryImpl) findOne(ctx context.Context, op, stmt string, args ...interface{}) (*entity.Order, error) {
 	order := new(entity.Order)
-	ticket := new(entity.Ticket)
-	if err := r.SQL.QueryRowContext(ctx, stmt, orderID).Scan(
+	var reason, note sql.NullString
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, args...).Scan(
 		&order.ID,
 		&order.Status,
 		&order.ExpiresAt,
+		&order.CreatedAt,
 		&order.UserID,
+		&order.Total.Amount,
+		&order.Total.Currency,
 		&order.Version,
-		&ticket.ID,
-		&ticket.Title,
-		&ticket.Price,
-		&ticket.Version,
+		&reason,
+		&note,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{
 				Code:    common.ENOTFOUND,
-				Op:      "OrderRepository.FindOne",
+				Op:      op,
 				Message: "Order Not Found",
 				Err:     err,
 			}
 		}
-		return nil, &common.Error{Op: "OrderRepository.FindOne", Err: err}
+		return nil, &common.Error{Op: op, Err: err}
+	}
+	order.Cancellation = newCancellation(reason, note)
+
+	if err := r.loadItems(ctx, []*entity.Order{order}); err != nil {
+		return nil, &common.Error{Op: op, Err: err}
 	}
-	order.Ticket = ticket
 
 	return order, nil
 }
 
-func (r *OrderRepositoryImpl) FindOneByTicketID(ticketID int64) (*entity.Order, error) {
-	ctx, cancel := newDBContext()
-	defer cancel()
+// loadItems fetches the items of all given orders with a single query.
+func (r *OrderRepositoryImpl) loadItems(ctx context.Context, orders []*entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
 
-	stmt := `SELECT o.id, status, expires_at, user_id, o.version, t.id, title, price, t.version
-	FROM orders AS o JOIN tickets AS t
-	ON o.ticket_id = t.id
-	WHERE t.id = $1`
+	byID := make(map[int64]*entity.Order, len(orders))
+	orderIDs := make([]int64, 0, len(orders))
+	for _, order := range orders {
+		order.Items = make([]*entity.OrderItem, 0)
+		byID[order.ID] = order
+		orderIDs = append(orderIDs, order.ID)
+	}
 
-	order := new(entity.Order)
-	ticket := new(entity.Ticket)
-	if err := r.SQL.QueryRowContext(ctx, stmt, ticketID).Scan(
-		&order.ID,
-		&order.Status,
-		&order.ExpiresAt,
-		&order.UserID,
-		&order.Version,
-		&ticket.ID,
-		&ticket.Title,
-		&ticket.Price,
-		&ticket.Version,
-	); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, &common.Error{
-				Code:    common.ENOTFOUND,
-				Op:      "OrderRepository.FindOne",
-				Message: "Order Not Found",
-				Err:     err,
-			}
+	stmt := `SELECT i.id, i.order_id, i.title, i.quantity, i.unit_price, i.currency, t.id, t.title, t.price, t.currency, t.version
+	FROM order_items AS i JOIN tickets AS t
+	ON i.ticket_id = t.id
+	WHERE i.order_id = ANY($1)
+	ORDER BY i.id`
+
+	rows, err := conn(ctx, r.SQL).QueryContext(ctx, stmt, orderIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := new(entity.OrderItem)
+		ticket := new(entity.Ticket)
+		if err := rows.Scan(
+			&item.ID,
+			&item.OrderID,
+			&item.Title,
+			&item.Quantity,
+			&item.UnitPrice.Amount,
+			&item.UnitPrice.Currency,
+			&ticket.ID,
+			&ticket.Title,
+			&ticket.Price.Amount,
+			&ticket.Price.Currency,
+			&ticket.Version,
+		); err != nil {
+			return err
 		}
+		item.Ticket = ticket
 
-		return nil, &common.Error{Op: "OrderRepository.FindOne", Err: err}
+		order := byID[item.OrderID]
+		order.Items = append(order.Items, item)
 	}
-	order.Ticket = ticket
 
-	return order, nil
+	return rows.Err()
 }
 
-func (r *OrderRepositoryImpl) Update(order *entity.Order) (*entity.Order, error) {
-	ctx, cancel := newDBContext()
-	defer cancel()
+func newCancellation(reason, note sql.NullString) *entity.Cancellation {
+	if !reason.Valid {
+		return nil
+	}
 
-	stmt := `UPDATE orders
-	SET status = $1, version = $2
-	WHERE id = $3
-	RETURNING id, status, expires_at, user_id, version`
+	return &entity.Cancellation{ReasonCode: reason.String, Note: note.String}
+}
 
-	updatedOrder := new(entity.Order)
-	if err := r.SQL.QueryRowContext(ctx, stmt, order.Status, order.Version+1, order.ID).Scan(
-		&updatedOrder.ID,
-		&updatedOrder.Status,
-		&updatedOrder.ExpiresAt,
-		&updatedOrder.UserID,
-		&updatedOrder.Version,
-	); err != nil {
-		return nil, &common.Error{Op: "OrderRepository.Update", Err: err}
+func scanOrders(rows *sql.Rows) ([]*entity.Order, error) {
+	defer rows.Close()
+
+	orders := make([]*entity.Order, 0)
+	for rows.Next() {
+		order := new(entity.Order)
+		var reason, note sql.NullString
+		if err := rows.Scan(
+			&order.ID,
+			&order.Status,
+			&order.ExpiresAt,
+			&order.CreatedAt,
+			&order.UserID,
+			&order.Total.Amount,
+			&order.Total.Currency,
+			&order.Version,
+			&reason,
+			&note,
+		); err != nil {
+			return nil, err
+		}
+		order.Cancellation = newCancellation(reason, note)
+		orders = append(orders, order)
 	}
-	updatedOrder.Ticket = order.Ticket
 
-	return updatedOrder, nil
+	return orders, rows.Err()
 }
 
-func (r *OrderRepositoryImpl) UpdateOnEvent(order *entity.Order) (*entity.Order, error) {
-	ctx, cancel := newDBContext()
+// Update writes the order status only if the stored version still matches
+// order.Version, so a change based on a stale read never overwrites a newer
+// one.
+func (r *OrderRepositoryImpl) Update(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
 	defer cancel()
 
 	stmt := `UPDATE orders
-	SET status = $1, version = $2
-	WHERE id = $3 AND version = $4
-	RETURNING id, status, expires_at, user_id, version`
+	SET status = $1, cancellation_reason = $2, cancellation_note = $3, version = version + 1
+	WHERE id = $4 AND version = $5
+	RETURNING id, status, expires_at, created_at, user_id, total, currency, version, cancellation_reason, cancellation_note`
+
+	var reason, note sql.NullString
+	if order.Cancellation != nil {
+		reason = sql.NullString{String: order.Cancellation.ReasonCode, Valid: true}
+		note = sql.NullString{String: order.Cancellation.Note, Valid: order.Cancellation.Note != ""}
+	}
 
 	updatedOrder := new(entity.Order)
-	if err := r.SQL.QueryRowContext(
+	if err := conn(ctx, r.SQL).QueryRowContext(
 		ctx,
 		stmt,
 		order.Status,
-		order.Version,
+		reason,
+		note,
 		order.ID,
-		order.Version-1,
+		order.Version,
 	).Scan(
 		&updatedOrder.ID,
 		&updatedOrder.Status,
 		&updatedOrder.ExpiresAt,
+		&updatedOrder.CreatedAt,
 		&updatedOrder.UserID,
+		&updatedOrder.Total.Amount,
+		&updatedOrder.Total.Currency,
 		&updatedOrder.Version,
+		&reason,
+		&note,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{
-				Code:    common.ENOTFOUND,
-				Message: "Order not found. Version probably out of sync",
-				Op:      "OrderRepository.UpdateOnEvent",
-				Err:     err,
+				Code:    common.ECONCLICT,
+				Op:      "OrderRepository.Update",
+				Message: "Order has been modified by another request",
+				Err:     ErrVersionConflict,
 			}
 		}
-		return nil, &common.Error{Op: "orderRepository.UpdateOnEvent", Err: err}
+		return nil, &common.Error{Op: "OrderRepository.Update", Err: err}
 	}
-	updatedOrder.Ticket = order.Ticket
+	updatedOrder.Cancellation = newCancellation(reason, note)
+	updatedOrder.Items = order.Items
 
 	return updatedOrder, nil
 }
+
+// FindExpired returns CREATED orders that expired before the given time,
+// leaving out the orders in skip. The rows stay locked until the surrounding
+// transaction ends and rows locked by another transaction are skipped, so
+// concurrent sweepers never pick the same order.
+func (r *OrderRepositoryImpl) FindExpired(ctx context.Context, before time.Time, skip []int64, limit int) ([]int64, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT id FROM orders
+	WHERE status = 'CREATED' AND expires_at < $1 AND NOT (id = ANY($2))
+	ORDER BY expires_at
+	LIMIT $3
+	FOR UPDATE SKIP LOCKED`
+
+	if skip == nil {
+		skip = []int64{}
+	}
+	rows, err := conn(ctx, r.SQL).QueryContext(ctx, stmt, before, skip, limit)
+	if err != nil {
+		return nil, &common.Error{Op: "OrderRepository.FindExpired", Err: err}
+	}
+	defer rows.Close()
+
+	orderIDs := make([]int64, 0)
+	for rows.Next() {
+		var orderID int64
+		if err := rows.Scan(&orderID); err != nil {
+			return nil, &common.Error{Op: "OrderRepository.FindExpired", Err: err}
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+
+	return orderIDs, rows.Err()
+}
+
+// userLockSpace namespaces the advisory locks taken by LockUser.
+const userLockSpace = 1
+
+// LockUser serializes order placement of the user until the surrounding
+// transaction ends, so that limits are checked against every order of theirs.
+func (r *OrderRepositoryImpl) LockUser(ctx context.Context, userID int64) error {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `SELECT pg_advisory_xact_lock($1, $2)`
+	if _, err := conn(ctx, r.SQL).ExecContext(ctx, stmt, userLockSpace, userID); err != nil {
+		return &common.Error{Op: "OrderRepository.LockUser", Err: err}
+	}
+
+	return nil
+}
+
+// CountActive counts the orders of the user still waiting for payment.
+func (r *OrderRepositoryImpl) CountActive(ctx context.Context, userID int64) (int, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT COUNT(*) FROM orders
+	WHERE user_id = $1 AND status IN ('CREATED', 'PENDING')`
+
+	var count int
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, userID).Scan(&count); err != nil {
+		return 0, &common.Error{Op: "OrderRepository.CountActive", Err: err}
+	}
+
+	return count, nil
+}
+
+// CountByTitle counts the orders of the user holding or paid for a ticket
+// titled title, either when it was ordered or now. Unpaid orders count so
+// that they cannot all be paid past the limit, and orders being refunded
+// still count.
+func (r *OrderRepositoryImpl) CountByTitle(ctx context.Context, userID int64, title string) (int, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT COUNT(DISTINCT o.id)
+	FROM orders AS o JOIN order_items AS i
+	ON i.order_id = o.id
+	JOIN tickets AS t
+	ON i.ticket_id = t.id
+	WHERE o.user_id = $1 AND (i.title = $2 OR t.title = $2)
+	AND o.status IN ('CREATED', 'PENDING', 'COMPLETED', 'REFUND_REQUESTED')`
+
+	var count int
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, userID, title).Scan(&count); err != nil {
+		return 0, &common.Error{Op: "OrderRepository.CountByTitle", Err: err}
+	}
+
+	return count, nil
+}
+
+// CountExpired counts the orders of the user that expired since since, along
+// with the time of the latest expiration.
+func (r *OrderRepositoryImpl) CountExpired(ctx context.Context, userID int64, since time.Time) (int, time.Time, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT COUNT(*), MAX(e.created_at)
+	FROM order_events AS e JOIN orders AS o
+	ON o.id = e.order_id
+	WHERE o.user_id = $1 AND e.actor_type = 'EXPIRATION' AND e.to_status = 'CANCELLED' AND e.created_at >= $2`
+
+	var count int
+	var latest sql.NullTime
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, userID, since).Scan(&count, &latest); err != nil {
+		return 0, time.Time{}, &common.Error{Op: "OrderRepository.CountExpired", Err: err}
+	}
+
+	return count, latest.Time, nil
+}
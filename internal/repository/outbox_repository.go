@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type OutboxRepository interface {
+	Insert(ctx context.Context, msg *entity.OutboxMessage) error
+	Lock(ctx context.Context) (bool, error)
+	FindPending(ctx context.Context, limit int) ([]*entity.OutboxMessage, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, retryIn time.Duration, reason string) error
+}
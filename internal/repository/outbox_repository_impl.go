@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type OutboxRepositoryImpl struct {
+	*driver.DB
+}
+
+func NewOutboxRepository(db *driver.DB) OutboxRepository {
+	return &OutboxRepositoryImpl{
+		DB: db,
+	}
+}
+
+func (r *OutboxRepositoryImpl) Insert(ctx context.Context, msg *entity.OutboxMessage) error {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	metadata, err := json.Marshal(msg.Metadata)
+	if err != nil {
+		return &common.Error{Op: "OutboxRepository.Insert", Err: err}
+	}
+
+	stmt := `INSERT INTO outbox (uuid, topic, payload, metadata)
+	VALUES ($1, $2, $3, $4)`
+
+	if _, err := conn(ctx, r.SQL).ExecContext(ctx, stmt, msg.UUID, msg.Topic, msg.Payload, metadata); err != nil {
+		return &common.Error{Op: "OutboxRepository.Insert", Err: err}
+	}
+
+	return nil
+}
+
+// outboxLockSpace namespaces the advisory lock taken by Lock.
+const outboxLockSpace = 2
+
+// Lock reports whether this transaction now relays the outbox. The lock is
+// held until the transaction ends, so only one relay sends messages at a
+// time and the order of each topic is kept across replicas.
+func (r *OutboxRepositoryImpl) Lock(ctx context.Context) (bool, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	var locked bool
+	stmt := `SELECT pg_try_advisory_xact_lock($1, 0)`
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, outboxLockSpace).Scan(&locked); err != nil {
+		return false, &common.Error{Op: "OutboxRepository.Lock", Err: err}
+	}
+
+	return locked, nil
+}
+
+// FindPending returns the due messages in the order they were written. A
+// message waiting for a retry holds back the later messages of its topic, so
+// they are never sent ahead of it.
+func (r *OutboxRepositoryImpl) FindPending(ctx context.Context, limit int) ([]*entity.OutboxMessage, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT id, uuid, topic, payload, metadata, attempts, next_attempt_at, created_at
+	FROM outbox o
+	WHERE published_at IS NULL AND next_attempt_at <= NOW() AT TIME ZONE 'UTC'
+	AND NOT EXISTS (
+		SELECT 1 FROM outbox earlier
+		WHERE earlier.topic = o.topic AND earlier.id < o.id
+		AND earlier.published_at IS NULL AND earlier.next_attempt_at > NOW() AT TIME ZONE 'UTC'
+	)
+	ORDER BY id
+	LIMIT $1`
+
+	rows, err := conn(ctx, r.SQL).QueryContext(ctx, stmt, limit)
+	if err != nil {
+		return nil, &common.Error{Op: "OutboxRepository.FindPending", Err: err}
+	}
+	defer rows.Close()
+
+	messages := make([]*entity.OutboxMessage, 0)
+	for rows.Next() {
+		msg := new(entity.OutboxMessage)
+		var metadata []byte
+		if err := rows.Scan(
+			&msg.ID,
+			&msg.UUID,
+			&msg.Topic,
+			&msg.Payload,
+			&metadata,
+			&msg.Attempts,
+			&msg.NextAttemptAt,
+			&msg.CreatedAt,
+		); err != nil {
+			return nil, &common.Error{Op: "OutboxRepository.FindPending", Err: err}
+		}
+		if err := json.Unmarshal(metadata, &msg.Metadata); err != nil {
+			return nil, &common.Error{Op: "OutboxRepository.FindPending", Err: err}
+		}
+
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+func (r *OutboxRepositoryImpl) MarkPublished(ctx context.Context, id int64) error {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `UPDATE outbox
+	SET published_at = NOW() AT TIME ZONE 'UTC', last_error = NULL
+	WHERE id = $1`
+
+	if _, err := conn(ctx, r.SQL).ExecContext(ctx, stmt, id); err != nil {
+		return &common.Error{Op: "OutboxRepository.MarkPublished", Err: err}
+	}
+
+	return nil
+}
+
+func (r *OutboxRepositoryImpl) MarkFailed(ctx context.Context, id int64, retryIn time.Duration, reason string) error {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `UPDATE outbox
+	SET attempts = attempts + 1, next_attempt_at = NOW() AT TIME ZONE 'UTC' + $1 * INTERVAL '1 second', last_error = $2
+	WHERE id = $3`
+
+	if _, err := conn(ctx, r.SQL).ExecContext(ctx, stmt, retryIn.Seconds(), reason, id); err != nil {
+		return &common.Error{Op: "OutboxRepository.MarkFailed", Err: err}
+	}
+
+	return nil
+}
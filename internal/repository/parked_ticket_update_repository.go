@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type ParkedTicketUpdateRepository interface {
+	Insert(ctx context.Context, update *entity.ParkedTicketUpdate) error
+	FindOne(ctx context.Context, ticketID, version int64) (*entity.ParkedTicketUpdate, error)
+	FindLatest(ctx context.Context, ticketID int64) (*entity.ParkedTicketUpdate, error)
+	FindStale(ctx context.Context, before time.Time, limit int) ([]int64, error)
+	Delete(ctx context.Context, ticketID, upToVersion int64) error
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type ParkedTicketUpdateRepositoryImpl struct {
+	*driver.DB
+}
+
+func NewParkedTicketUpdateRepository(db *driver.DB) ParkedTicketUpdateRepository {
+	return &ParkedTicketUpdateRepositoryImpl{
+		DB: db,
+	}
+}
+
+// Insert parks the update. Parking the same version twice keeps the first.
+func (r *ParkedTicketUpdateRepositoryImpl) Insert(ctx context.Context, update *entity.ParkedTicketUpdate) error {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `INSERT INTO parked_ticket_updates (ticket_id, version, title, price, currency, parked_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (ticket_id, version) DO NOTHING`
+
+	if _, err := conn(ctx, r.SQL).ExecContext(
+		ctx,
+		stmt,
+		update.ID,
+		update.Version,
+		update.Title,
+		update.Price.Amount,
+		update.Price.Currency,
+		update.ParkedAt,
+	); err != nil {
+		return &common.Error{Op: "ParkedTicketUpdateRepository.Insert", Err: err}
+	}
+
+	return nil
+}
+
+func (r *ParkedTicketUpdateRepositoryImpl) FindOne(ctx context.Context, ticketID, version int64) (*entity.ParkedTicketUpdate, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT ticket_id, version, title, price, currency, parked_at
+	FROM parked_ticket_updates
+	WHERE ticket_id = $1 AND version = $2`
+
+	return r.findOne(ctx, "ParkedTicketUpdateRepository.FindOne", stmt, ticketID, version)
+}
+
+// FindLatest returns the parked update of the ticket with the highest
+// version.
+func (r *ParkedTicketUpdateRepositoryImpl) FindLatest(ctx context.Context, ticketID int64) (*entity.ParkedTicketUpdate, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT ticket_id, version, title, price, currency, parked_at
+	FROM parked_ticket_updates
+	WHERE ticket_id = $1
+	ORDER BY version DESC
+	LIMIT 1`
+
+	return r.findOne(ctx, "ParkedTicketUpdateRepository.FindLatest", stmt, ticketID)
+}
+
+// FindStale returns the tickets with updates parked before before. The
+// returned rows stay locked until the surrounding transaction ends, and rows
+// locked by another transaction are skipped.
+func (r *ParkedTicketUpdateRepositoryImpl) FindStale(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT ticket_id FROM parked_ticket_updates
+	WHERE parked_at < $1
+	ORDER BY parked_at
+	LIMIT $2
+	FOR UPDATE SKIP LOCKED`
+
+	rows, err := conn(ctx, r.SQL).QueryContext(ctx, stmt, before, limit)
+	if err != nil {
+		return nil, &common.Error{Op: "ParkedTicketUpdateRepository.FindStale", Err: err}
+	}
+	defer rows.Close()
+
+	var ticketIDs []int64
+	for rows.Next() {
+		var ticketID int64
+		if err := rows.Scan(&ticketID); err != nil {
+			return nil, &common.Error{Op: "ParkedTicketUpdateRepository.FindStale", Err: err}
+		}
+		ticketIDs = append(ticketIDs, ticketID)
+	}
+
+	return ticketIDs, rows.Err()
+}
+
+// Delete drops the parked updates of the ticket up to and including
+// upToVersion.
+func (r *ParkedTicketUpdateRepositoryImpl) Delete(ctx context.Context, ticketID, upToVersion int64) error {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `DELETE FROM parked_ticket_updates
+	WHERE ticket_id = $1 AND version <= $2`
+
+	if _, err := conn(ctx, r.SQL).ExecContext(ctx, stmt, ticketID, upToVersion); err != nil {
+		return &common.Error{Op: "ParkedTicketUpdateRepository.Delete", Err: err}
+	}
+
+	return nil
+}
+
+func (r *ParkedTicketUpdateRepositoryImpl) findOne(ctx context.Context, op, stmt string, args ...interface{}) (*entity.ParkedTicketUpdate, error) {
+	update := &entity.ParkedTicketUpdate{Ticket: new(entity.Ticket)}
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, args...).Scan(
+		&update.ID,
+		&update.Version,
+		&update.Title,
+		&update.Price.Amount,
+		&update.Price.Currency,
+		&update.ParkedAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{
+				Code:    common.ENOTFOUND,
+				Op:      op,
+				Message: "Parked ticket update not found",
+				Err:     err,
+			}
+		}
+		return nil, &common.Error{Op: op, Err: err}
+	}
+
+	return update, nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type PaymentRepository interface {
+	Insert(ctx context.Context, payment *entity.Payment) (bool, error)
+}
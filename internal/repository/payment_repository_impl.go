@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type PaymentRepositoryImpl struct {
+	*driver.DB
+}
+
+func NewPaymentRepository(db *driver.DB) PaymentRepository {
+	return &PaymentRepositoryImpl{
+		DB: db,
+	}
+}
+
+// Insert records the payment and reports false when a payment with the same
+// id was recorded before.
+func (r *PaymentRepositoryImpl) Insert(ctx context.Context, payment *entity.Payment) (bool, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `INSERT INTO order_payments (payment_id, order_id, stripe_id, status, reason, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (payment_id) DO NOTHING`
+
+	result, err := conn(ctx, r.SQL).ExecContext(
+		ctx,
+		stmt,
+		payment.ID,
+		payment.OrderID,
+		payment.StripeID,
+		payment.Status,
+		payment.Reason,
+		payment.CreatedAt,
+	)
+	if err != nil {
+		return false, &common.Error{Op: "PaymentRepository.Insert", Err: err}
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, &common.Error{Op: "PaymentRepository.Insert", Err: err}
+	}
+
+	return inserted == 1, nil
+}
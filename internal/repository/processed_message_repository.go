@@ -0,0 +1,7 @@
+package repository
+
+import "context"
+
+type ProcessedMessageRepository interface {
+	Insert(ctx context.Context, messageID, topic string) (bool, error)
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+)
+
+type ProcessedMessageRepositoryImpl struct {
+	*driver.DB
+}
+
+func NewProcessedMessageRepository(db *driver.DB) ProcessedMessageRepository {
+	return &ProcessedMessageRepositoryImpl{
+		DB: db,
+	}
+}
+
+// Insert records the message as processed and reports false when it already
+// was. Run inside the transaction of the message's side effects, a concurrent
+// redelivery waits for that transaction and is then reported as processed.
+func (r *ProcessedMessageRepositoryImpl) Insert(ctx context.Context, messageID, topic string) (bool, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `INSERT INTO processed_messages (message_id, topic)
+	VALUES ($1, $2)
+	ON CONFLICT (message_id) DO NOTHING`
+
+	result, err := conn(ctx, r.SQL).ExecContext(ctx, stmt, messageID, topic)
+	if err != nil {
+		return false, &common.Error{Op: "ProcessedMessageRepository.Insert", Err: err}
+	}
+
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, &common.Error{Op: "ProcessedMessageRepository.Insert", Err: err}
+	}
+
+	return inserted == 1, nil
+}
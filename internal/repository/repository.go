@@ -2,9 +2,36 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
-func newDBContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), 3*time.Second)
+const defaultTimeout = 3 * time.Second
+
+// DBTX is satisfied by both *sql.DB and *sql.Tx so repositories can run
+// inside or outside of a transaction.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type txKey struct{}
+
+// conn returns the transaction started by Transactor.WithinTransaction when
+// ctx carries one, and db otherwise.
+func conn(ctx context.Context, db *sql.DB) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db
+}
+
+func newDBContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return context.WithTimeout(ctx, timeout)
 }
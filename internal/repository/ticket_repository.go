@@ -1,12 +1,16 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/muktiarafi/ticketing-orders/internal/entity"
 )
 
 type TicketRepository interface {
-	Insert(ticket *entity.Ticket) (*entity.Ticket, error)
-	FindOne(ticketId int64) (*entity.Ticket, error)
-	Update(ticket *entity.Ticket) (*entity.Ticket, error)
-	UpdateByEvent(ticket *entity.Ticket) (*entity.Ticket, error)
+	Insert(ctx context.Context, ticket *entity.Ticket) (*entity.Ticket, error)
+	FindOne(ctx context.Context, ticketId int64) (*entity.Ticket, error)
+	FindOneForUpdate(ctx context.Context, ticketID int64) (*entity.Ticket, error)
+	Update(ctx context.Context, ticket *entity.Ticket) (*entity.Ticket, error)
+	UpdateByEvent(ctx context.Context, ticket *entity.Ticket) (*entity.Ticket, error)
+	Restore(ctx context.Context, ticket *entity.Ticket) (*entity.Ticket, error)
 }
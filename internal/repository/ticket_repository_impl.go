@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 
 	common "github.com/muktiarafi/ticketing-common"
@@ -18,19 +19,20 @@ func NewTicketRepository(db *driver.DB) TicketRepository {
 	}
 }
 
-func (r *TicketRepositoryImpl) Insert(ticket *entity.Ticket) (*entity.Ticket, error) {
-	ctx, cancel := newDBContext()
+func (r *TicketRepositoryImpl) Insert(ctx context.Context, ticket *entity.Ticket) (*entity.Ticket, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
 	defer cancel()
 
-	stmt := `INSERT INTO tickets (id, title, price)
-	VALUES ($1, $2, $3)
-	RETURNING *`
+	stmt := `INSERT INTO tickets (id, title, price, currency)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, title, price, currency, version`
 
 	newTicket := new(entity.Ticket)
-	if err := r.SQL.QueryRowContext(ctx, stmt, ticket.ID, ticket.Title, ticket.Price).Scan(
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, ticket.ID, ticket.Title, ticket.Price.Amount, ticket.Price.Currency).Scan(
 		&newTicket.ID,
 		&newTicket.Title,
-		&newTicket.Price,
+		&newTicket.Price.Amount,
+		&newTicket.Price.Currency,
 		&newTicket.Version,
 	); err != nil {
 		return nil, &common.Error{Op: "TicketRepository.Insert", Err: err}
@@ -39,18 +41,19 @@ func (r *TicketRepositoryImpl) Insert(ticket *entity.Ticket) (*entity.Ticket, er
 	return newTicket, nil
 }
 
-func (r *TicketRepositoryImpl) FindOne(ticketID int64) (*entity.Ticket, error) {
-	ctx, cancel := newDBContext()
+func (r *TicketRepositoryImpl) FindOne(ctx context.Context, ticketID int64) (*entity.Ticket, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
 	defer cancel()
 
-	stmt := `SELECT * FROM tickets
+	stmt := `SELECT id, title, price, currency, version FROM tickets
 	WHERE id = $1`
 
 	ticket := new(entity.Ticket)
-	if err := r.SQL.QueryRowContext(ctx, stmt, ticketID).Scan(
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, ticketID).Scan(
 		&ticket.ID,
 		&ticket.Title,
-		&ticket.Price,
+		&ticket.Price.Amount,
+		&ticket.Price.Currency,
 		&ticket.Version,
 	); err != nil {
 		if err == sql.ErrNoRows {
@@ -67,27 +70,61 @@ func (r *TicketRepositoryImpl) FindOne(ticketID int64) (*entity.Ticket, error) {
 	return ticket, nil
 }
 
-func (r *TicketRepositoryImpl) Update(ticket *entity.Ticket) (*entity.Ticket, error) {
-	ctx, cancel := newDBContext()
+// FindOneForUpdate locks the ticket row until the surrounding transaction
+// ends, serializing reservations of the same ticket.
+func (r *TicketRepositoryImpl) FindOneForUpdate(ctx context.Context, ticketID int64) (*entity.Ticket, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT id, title, price, currency, version FROM tickets
+	WHERE id = $1
+	FOR UPDATE`
+
+	ticket := new(entity.Ticket)
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, ticketID).Scan(
+		&ticket.ID,
+		&ticket.Title,
+		&ticket.Price.Amount,
+		&ticket.Price.Currency,
+		&ticket.Version,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{
+				Code:    common.ENOTFOUND,
+				Op:      "TicketRepository.FindOneForUpdate",
+				Message: "Ticket Not Found",
+				Err:     err,
+			}
+		}
+		return nil, &common.Error{Op: "TicketRepository.FindOneForUpdate", Err: err}
+	}
+
+	return ticket, nil
+}
+
+func (r *TicketRepositoryImpl) Update(ctx context.Context, ticket *entity.Ticket) (*entity.Ticket, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
 	defer cancel()
 
 	stmt := `UPDATE tickets
-	SET title = $1, price =$2, version = $3
-	WHERE id = $4
-	RETURNING *`
+	SET title = $1, price = $2, currency = $3, version = $4
+	WHERE id = $5
+	RETURNING id, title, price, currency, version`
 
 	updatedTicket := new(entity.Ticket)
-	if err := r.SQL.QueryRowContext(
+	if err := conn(ctx, r.SQL).QueryRowContext(
 		ctx,
 		stmt,
 		ticket.Title,
-		ticket.Price,
+		ticket.Price.Amount,
+		ticket.Price.Currency,
 		ticket.Version+1,
 		ticket.ID,
 	).Scan(
 		&updatedTicket.ID,
 		&updatedTicket.Title,
-		&updatedTicket.Price,
+		&updatedTicket.Price.Amount,
+		&updatedTicket.Price.Currency,
 		&updatedTicket.Version,
 	); err != nil {
 		if err == sql.ErrNoRows {
@@ -104,28 +141,30 @@ func (r *TicketRepositoryImpl) Update(ticket *entity.Ticket) (*entity.Ticket, er
 	return updatedTicket, nil
 }
 
-func (r *TicketRepositoryImpl) UpdateByEvent(ticket *entity.Ticket) (*entity.Ticket, error) {
-	ctx, cancel := newDBContext()
+func (r *TicketRepositoryImpl) UpdateByEvent(ctx context.Context, ticket *entity.Ticket) (*entity.Ticket, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
 	defer cancel()
 
 	stmt := `UPDATE tickets
-	SET title = $1, price =$2, version = $3
-	WHERE id = $4 AND version = $5
-	RETURNING *`
+	SET title = $1, price = $2, currency = $3, version = $4
+	WHERE id = $5 AND version = $6
+	RETURNING id, title, price, currency, version`
 
 	updatedTicket := new(entity.Ticket)
-	if err := r.SQL.QueryRowContext(
+	if err := conn(ctx, r.SQL).QueryRowContext(
 		ctx,
 		stmt,
 		ticket.Title,
-		ticket.Price,
+		ticket.Price.Amount,
+		ticket.Price.Currency,
 		ticket.Version,
 		ticket.ID,
 		ticket.Version-1,
 	).Scan(
 		&updatedTicket.ID,
 		&updatedTicket.Title,
-		&updatedTicket.Price,
+		&updatedTicket.Price.Amount,
+		&updatedTicket.Price.Currency,
 		&updatedTicket.Version,
 	); err != nil {
 		if err == sql.ErrNoRows {
@@ -141,3 +180,31 @@ func (r *TicketRepositoryImpl) UpdateByEvent(ticket *entity.Ticket) (*entity.Tic
 
 	return updatedTicket, nil
 }
+
+// Restore writes the ticket at its version whatever versions are missing in
+// between, creating it if needed. An older version than the stored one is
+// ignored and the stored ticket returned.
+func (r *TicketRepositoryImpl) Restore(ctx context.Context, ticket *entity.Ticket) (*entity.Ticket, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `INSERT INTO tickets (id, title, price, currency, version)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (id) DO UPDATE
+	SET title = EXCLUDED.title, price = EXCLUDED.price, currency = EXCLUDED.currency, version = EXCLUDED.version
+	WHERE tickets.version < EXCLUDED.version`
+
+	if _, err := conn(ctx, r.SQL).ExecContext(
+		ctx,
+		stmt,
+		ticket.ID,
+		ticket.Title,
+		ticket.Price.Amount,
+		ticket.Price.Currency,
+		ticket.Version,
+	); err != nil {
+		return nil, &common.Error{Op: "TicketRepository.Restore", Err: err}
+	}
+
+	return r.FindOne(ctx, ticket.ID)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+)
+
+type TransactorImpl struct {
+	*driver.DB
+}
+
+func NewTransactor(db *driver.DB) Transactor {
+	return &TransactorImpl{
+		DB: db,
+	}
+}
+
+// WithinTransaction commits when fn returns nil and rolls back otherwise.
+// Repositories called with the context handed to fn run inside the
+// transaction, and nested calls join the outer transaction.
+func (t *TransactorImpl) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return &common.Error{Op: "Transactor.WithinTransaction", Err: err}
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &common.Error{Op: "Transactor.WithinTransaction", Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type WaitlistRepository interface {
+	Insert(ctx context.Context, entry *entity.WaitlistEntry) (*entity.WaitlistEntry, error)
+	FindWaiting(ctx context.Context, ticketID, userID int64) (*entity.WaitlistEntry, error)
+	FindNext(ctx context.Context, ticketID int64) (*entity.WaitlistEntry, error)
+	UpdateStatus(ctx context.Context, entry *entity.WaitlistEntry) error
+	CountWaiting(ctx context.Context, ticketID int64) (int, error)
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgconn"
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+const uniqueViolation = "23505"
+
+type WaitlistRepositoryImpl struct {
+	*driver.DB
+}
+
+func NewWaitlistRepository(db *driver.DB) WaitlistRepository {
+	return &WaitlistRepositoryImpl{
+		DB: db,
+	}
+}
+
+func (r *WaitlistRepositoryImpl) Insert(ctx context.Context, entry *entity.WaitlistEntry) (*entity.WaitlistEntry, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `INSERT INTO waitlist_entries (ticket_id, user_id, created_at, updated_at)
+	VALUES ($1, $2, $3, $3)
+	RETURNING id, ticket_id, user_id, status, created_at`
+
+	newEntry := new(entity.WaitlistEntry)
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, entry.TicketID, entry.UserID, entry.CreatedAt).Scan(
+		&newEntry.ID,
+		&newEntry.TicketID,
+		&newEntry.UserID,
+		&newEntry.Status,
+		&newEntry.CreatedAt,
+	); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == uniqueViolation {
+			return nil, &common.Error{
+				Code:    common.ECONCLICT,
+				Op:      "WaitlistRepository.Insert",
+				Message: "Already on the waitlist for this ticket",
+				Err:     err,
+			}
+		}
+		return nil, &common.Error{Op: "WaitlistRepository.Insert", Err: err}
+	}
+
+	if err := r.position(ctx, newEntry); err != nil {
+		return nil, &common.Error{Op: "WaitlistRepository.Insert", Err: err}
+	}
+
+	return newEntry, nil
+}
+
+// FindWaiting returns the waiting entry of the user for the ticket.
+func (r *WaitlistRepositoryImpl) FindWaiting(ctx context.Context, ticketID, userID int64) (*entity.WaitlistEntry, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT id, ticket_id, user_id, status, created_at
+	FROM waitlist_entries
+	WHERE ticket_id = $1 AND user_id = $2 AND status = 'WAITING'`
+
+	entry, err := r.findOne(ctx, "WaitlistRepository.FindWaiting", stmt, ticketID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.position(ctx, entry); err != nil {
+		return nil, &common.Error{Op: "WaitlistRepository.FindWaiting", Err: err}
+	}
+
+	return entry, nil
+}
+
+// FindNext returns the first waiting entry of the ticket and locks it until
+// the surrounding transaction ends.
+func (r *WaitlistRepositoryImpl) FindNext(ctx context.Context, ticketID int64) (*entity.WaitlistEntry, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT id, ticket_id, user_id, status, created_at
+	FROM waitlist_entries
+	WHERE ticket_id = $1 AND status = 'WAITING'
+	ORDER BY id
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED`
+
+	return r.findOne(ctx, "WaitlistRepository.FindNext", stmt, ticketID)
+}
+
+func (r *WaitlistRepositoryImpl) UpdateStatus(ctx context.Context, entry *entity.WaitlistEntry) error {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Write)
+	defer cancel()
+
+	stmt := `UPDATE waitlist_entries
+	SET status = $1, order_id = NULLIF($2, 0), updated_at = NOW() AT TIME ZONE 'UTC'
+	WHERE id = $3`
+
+	if _, err := conn(ctx, r.SQL).ExecContext(ctx, stmt, entry.Status, entry.OrderID, entry.ID); err != nil {
+		return &common.Error{Op: "WaitlistRepository.UpdateStatus", Err: err}
+	}
+
+	return nil
+}
+
+func (r *WaitlistRepositoryImpl) CountWaiting(ctx context.Context, ticketID int64) (int, error) {
+	ctx, cancel := newDBContext(ctx, r.Timeouts.Read)
+	defer cancel()
+
+	stmt := `SELECT COUNT(*) FROM waitlist_entries
+	WHERE ticket_id = $1 AND status = 'WAITING'`
+
+	var count int
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, ticketID).Scan(&count); err != nil {
+		return 0, &common.Error{Op: "WaitlistRepository.CountWaiting", Err: err}
+	}
+
+	return count, nil
+}
+
+func (r *WaitlistRepositoryImpl) findOne(ctx context.Context, op, stmt string, args ...interface{}) (*entity.WaitlistEntry, error) {
+	entry := new(entity.WaitlistEntry)
+	if err := conn(ctx, r.SQL).QueryRowContext(ctx, stmt, args...).Scan(
+		&entry.ID,
+		&entry.TicketID,
+		&entry.UserID,
+		&entry.Status,
+		&entry.CreatedAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{
+				Code:    common.ENOTFOUND,
+				Op:      op,
+				Message: "Waitlist entry not found",
+				Err:     err,
+			}
+		}
+		return nil, &common.Error{Op: op, Err: err}
+	}
+
+	return entry, nil
+}
+
+func (r *WaitlistRepositoryImpl) position(ctx context.Context, entry *entity.WaitlistEntry) error {
+	stmt := `SELECT COUNT(*) FROM waitlist_entries
+	WHERE ticket_id = $1 AND status = 'WAITING' AND id <= $2`
+
+	return conn(ctx, r.SQL).QueryRowContext(ctx, stmt, entry.TicketID, entry.ID).Scan(&entry.Position)
+}
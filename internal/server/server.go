@@ -1,71 +1,264 @@
 package server
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"sync"
 
 	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/clock"
 	"github.com/muktiarafi/ticketing-orders/internal/config"
 	"github.com/muktiarafi/ticketing-orders/internal/driver"
 	"github.com/muktiarafi/ticketing-orders/internal/events/consumer"
+	"github.com/muktiarafi/ticketing-orders/internal/events/outbox"
 	"github.com/muktiarafi/ticketing-orders/internal/events/producer"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
 	"github.com/muktiarafi/ticketing-orders/internal/handler"
+	"github.com/muktiarafi/ticketing-orders/internal/health"
 	custommiddleware "github.com/muktiarafi/ticketing-orders/internal/middleware"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
 	"github.com/muktiarafi/ticketing-orders/internal/repository"
 	"github.com/muktiarafi/ticketing-orders/internal/service"
+	"github.com/muktiarafi/ticketing-orders/internal/statemachine"
+	"github.com/muktiarafi/ticketing-orders/internal/sweeper"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-func SetupServer() *echo.Echo {
+// Server owns the HTTP server together with the broker connections, database
+// pool and background workers, so they can be shut down in order.
+type Server struct {
+	*echo.Echo
+	db            *driver.DB
+	publisher     message.Publisher
+	dispatcher    *consumer.Dispatcher
+	cancelWorkers context.CancelFunc
+	workers       sync.WaitGroup
+}
+
+func SetupServer() *Server {
 	e := echo.New()
-	p := custommiddleware.NewPrometheus("echo", nil)
+	orderLimitRejections := &custommiddleware.Metric{
+		ID:          "orderLimitRejections",
+		Name:        "order_limit_rejections_total",
+		Description: "How many orders were rejected by per-user limits, partitioned by rule.",
+		Type:        "counter_vec",
+		Args:        []string{"rule"},
+	}
+	p := custommiddleware.NewPrometheus("echo", nil, []*custommiddleware.Metric{orderLimitRejections})
 	p.Use(e)
 
 	val := validator.New()
+	if err := money.RegisterValidations(val); err != nil {
+		log.Fatal(err)
+	}
 	trans := common.NewDefaultTranslator(val)
 	customValidator := &common.CustomValidator{val, trans}
 	e.Validator = customValidator
-	e.HTTPErrorHandler = common.CustomErrorHandler
+	e.HTTPErrorHandler = handler.ErrorHandler
 	e.Use(middleware.Logger())
 
-	db, err := driver.ConnectSQL(config.PostgresDSN())
+	db, err := driver.ConnectSQL(config.PostgresDSN(), driver.Timeouts{
+		Read:  config.PostgresReadTimeout(),
+		Write: config.PostgresWriteTimeout(),
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	orderRepository := repository.NewOrderRepository(db)
 	ticketRepository := repository.NewTicketRepository(db)
+	orderEventRepository := repository.NewOrderEventRepository(db)
+	waitlistRepository := repository.NewWaitlistRepository(db)
+	outboxRepository := repository.NewOutboxRepository(db)
+	transactor := repository.NewTransactor(db)
 
 	producerBrokers := []string{config.NewProducerBroker()}
 	commonPublisher, err := common.NewPublisher(producerBrokers, watermill.NewStdLogger(false, false))
 	if err != nil {
 		log.Fatal(err)
 	}
-	orderProducer := producer.NewOrderProducer(commonPublisher)
-	orderService := service.NewOrderService(orderRepository, ticketRepository, orderProducer)
+	workersCtx, cancelWorkers := context.WithCancel(context.Background())
+	s := &Server{
+		Echo:          e,
+		db:            db,
+		publisher:     commonPublisher,
+		cancelWorkers: cancelWorkers,
+	}
+
+	relay := outbox.NewRelay(
+		outboxRepository,
+		transactor,
+		commonPublisher,
+		config.OutboxRelayInterval(),
+		config.OutboxBatchSize(),
+	)
+	s.runWorker(workersCtx, relay.Run)
+
+	outboxPublisher := outbox.NewPublisher(outboxRepository)
+	orderProducer := producer.NewOrderProducer(outboxPublisher)
+	waitlistProducer := producer.NewWaitlistProducer(outboxPublisher)
+	orderMachine := statemachine.NewOrderMachine()
+	clk := clock.New()
+	orderService := service.NewOrderService(
+		orderRepository,
+		ticketRepository,
+		orderEventRepository,
+		waitlistRepository,
+		repository.NewPaymentRepository(db),
+		orderProducer,
+		waitlistProducer,
+		transactor,
+		orderMachine,
+		service.NewOrderLimiter(
+			orderRepository,
+			config.OrderLimits(),
+			orderLimitRejections.MetricCollector.(*prometheus.CounterVec),
+		),
+		config.OrderExpiration(),
+		clk,
+	)
+
+	if config.ExpirationSweeperEnabled() {
+		expirationSweeper := sweeper.NewExpirationSweeper(
+			orderRepository,
+			transactor,
+			orderService,
+			clk,
+			config.ExpirationSweeperInterval(),
+			config.ExpirationSweeperGrace(),
+			config.ExpirationSweeperBatchSize(),
+		)
+		s.runWorker(workersCtx, expirationSweeper.Run)
+	}
 
-	orderHandler := handler.NewOrderHandler(orderService)
+	idempotencyRepository := repository.NewIdempotencyRepository(db)
+	idempotency := custommiddleware.Idempotency(idempotencyRepository, config.IdempotencyKeyWindow(), config.IdempotencyKeyLease())
+	orderHandler := handler.NewOrderHandler(orderService, idempotency)
 	orderHandler.Route(e)
+	waitlistService := service.NewWaitlistService(waitlistRepository, ticketRepository, orderRepository, transactor, clk)
+	waitlistHandler := handler.NewWaitlistHandler(waitlistService)
+	waitlistHandler.Route(e)
+	adminService := service.NewAdminService(
+		orderService,
+		orderRepository,
+		ticketRepository,
+		orderEventRepository,
+		waitlistRepository,
+		repository.NewAdminActionRepository(db),
+		transactor,
+		clk,
+	)
+	adminHandler := handler.NewAdminHandler(adminService, custommiddleware.RequireAdmin(config.AdminUsers()))
+	adminHandler.Route(e)
 
+	consumerBrokers := []string{config.NewConsumerBroker()}
+	migrationFilePath, err := driver.MigrationFilePath()
+	if err != nil {
+		log.Fatal(err)
+	}
+	checker := health.NewChecker(config.HealthCheckTimeout())
+	checker.Register("postgres", health.PostgresCheck(db.SQL))
+	checker.Register("migrations", health.MigrationCheck(db.SQL, migrationFilePath))
 	subscriberConfig := &common.SubscriberConfig{
-		Brokers:       []string{config.NewConsumerBroker()},
+		Brokers:       consumerBrokers,
 		ConsumerGroup: "orders-service",
 		FromBeginning: true,
 		LoggerAdapter: watermill.NewStdLogger(false, false),
 	}
+	checker.Register("publisher", health.KafkaCheck(producerBrokers, ""))
+	checker.Register("subscriber", health.KafkaCheck(consumerBrokers, subscriberConfig.ConsumerGroup))
+	healthHandler := handler.NewHealthHandler(checker)
+	healthHandler.Route(e)
+
 	subscriber, err := common.NewSubscriber(subscriberConfig)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	orderConsumer := consumer.NewOrderConsumer(orderProducer, orderRepository, ticketRepository)
-	commonConsumer := common.NewConsumer(subscriber)
-	commonConsumer.On(common.TicketCreated, orderConsumer.TicketCreated)
-	commonConsumer.On(common.TIcketUpdated, orderConsumer.TicketUpdated)
-	commonConsumer.On(common.ExpirationComplete, orderConsumer.ExpirationComplete)
-	commonConsumer.On(common.PaymentCreated, orderConsumer.PaymentCreated)
+	parkedTicketUpdateRepository := repository.NewParkedTicketUpdateRepository(db)
+	ticketService := service.NewTicketService(
+		ticketRepository,
+		parkedTicketUpdateRepository,
+		producer.NewTicketProducer(outboxPublisher),
+		transactor,
+		clk,
+	)
+	ticketResyncer := sweeper.NewTicketResyncer(
+		parkedTicketUpdateRepository,
+		transactor,
+		ticketService,
+		clk,
+		config.TicketResyncInterval(),
+		config.TicketUpdateMaxWait(),
+		config.TicketResyncBatchSize(),
+	)
+	s.runWorker(workersCtx, ticketResyncer.Run)
+
+	orderConsumer := consumer.NewOrderConsumer(
+		ticketService,
+		repository.NewProcessedMessageRepository(db),
+		transactor,
+		orderService,
+		config.Currency(),
+	)
+	s.dispatcher = consumer.NewDispatcher(subscriber, commonPublisher, config.ConsumerRetry())
+	eventHandlers := map[string]common.EventHandler{
+		common.TicketCreated:      orderConsumer.TicketCreated,
+		common.TIcketUpdated:      orderConsumer.TicketUpdated,
+		common.ExpirationComplete: orderConsumer.ExpirationComplete,
+		common.PaymentCreated:     orderConsumer.PaymentCreated,
+		schema.RefundCompleted:    orderConsumer.RefundCompleted,
+	}
+	for topic, eventHandler := range eventHandlers {
+		if err := s.dispatcher.On(topic, eventHandler); err != nil {
+			log.Fatalf("could not subscribe to %s: %v", topic, err)
+		}
+	}
+
+	return s
+}
+
+func (s *Server) runWorker(ctx context.Context, run func(ctx context.Context)) {
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		run(ctx)
+	}()
+}
+
+// Shutdown drains HTTP requests first, then the event handlers, and stops the
+// background workers before closing the publisher and the database pool.
+// Whatever is still running when ctx is done gets cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	var errs []error
+	if err := s.Echo.Shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := s.dispatcher.Close(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	s.cancelWorkers()
+	s.workers.Wait()
+
+	if err := s.publisher.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := s.db.SQL.Close(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("shutdown: %v", errs)
+	}
 
-	return e
+	return nil
 }
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+)
+
+type AdminService interface {
+	FindOrders(ctx context.Context, admin *common.UserPayload, query *model.AdminOrderQuery) ([]*entity.Order, string, error)
+	ShowOrder(ctx context.Context, admin *common.UserPayload, orderID int64) (*entity.Order, error)
+	OrderHistory(ctx context.Context, admin *common.UserPayload, orderID int64) ([]*entity.OrderEvent, error)
+	OrderAudit(ctx context.Context, admin *common.UserPayload, orderID int64) ([]*entity.AdminAction, error)
+	CancelOrder(ctx context.Context, admin *common.UserPayload, orderID int64, note string) (*entity.Order, error)
+	CompleteOrder(ctx context.Context, admin *common.UserPayload, orderID int64, note string) (*entity.Order, error)
+	ShowTicket(ctx context.Context, admin *common.UserPayload, ticketID int64) (*entity.TicketReservation, error)
+}
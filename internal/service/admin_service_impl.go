@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/clock"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+)
+
+// AdminServiceImpl gives admins access to every order. Each action is
+// written to the audit trail; changes are audited in the same transaction.
+type AdminServiceImpl struct {
+	OrderService
+	repository.OrderRepository
+	repository.TicketRepository
+	repository.OrderEventRepository
+	repository.WaitlistRepository
+	repository.AdminActionRepository
+	repository.Transactor
+	clock clock.Clock
+}
+
+func NewAdminService(
+	orderSrv OrderService,
+	orderRepo repository.OrderRepository,
+	ticketRepo repository.TicketRepository,
+	orderEventRepo repository.OrderEventRepository,
+	waitlistRepo repository.WaitlistRepository,
+	adminActionRepo repository.AdminActionRepository,
+	transactor repository.Transactor,
+	clk clock.Clock,
+) AdminService {
+	return &AdminServiceImpl{
+		OrderService:          orderSrv,
+		OrderRepository:       orderRepo,
+		TicketRepository:      ticketRepo,
+		OrderEventRepository:  orderEventRepo,
+		WaitlistRepository:    waitlistRepo,
+		AdminActionRepository: adminActionRepo,
+		Transactor:            transactor,
+		clock:                 clk,
+	}
+}
+
+func (s *AdminServiceImpl) FindOrders(ctx context.Context, admin *common.UserPayload, query *model.AdminOrderQuery) ([]*entity.Order, string, error) {
+	orders, nextCursor, err := s.OrderService.Find(ctx, query.UserID, &query.OrderQuery)
+	if err != nil {
+		return nil, "", err
+	}
+
+	details, err := json.Marshal(query)
+	if err != nil {
+		return nil, "", &common.Error{Op: "AdminServiceImpl.FindOrders", Err: err}
+	}
+	if err := s.audit(ctx, admin, &entity.AdminAction{
+		Action:  constant.AdminSearchOrders,
+		Details: string(details),
+	}); err != nil {
+		return nil, "", err
+	}
+
+	return orders, nextCursor, nil
+}
+
+func (s *AdminServiceImpl) ShowOrder(ctx context.Context, admin *common.UserPayload, orderID int64) (*entity.Order, error) {
+	order, err := s.OrderRepository.FindOne(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, admin, &entity.AdminAction{
+		Action:  constant.AdminViewOrder,
+		OrderID: order.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s *AdminServiceImpl) OrderHistory(ctx context.Context, admin *common.UserPayload, orderID int64) ([]*entity.OrderEvent, error) {
+	if _, err := s.ShowOrder(ctx, admin, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.OrderEventRepository.Find(ctx, orderID)
+}
+
+// OrderAudit returns the admin actions taken on an order, including the
+// current one.
+func (s *AdminServiceImpl) OrderAudit(ctx context.Context, admin *common.UserPayload, orderID int64) ([]*entity.AdminAction, error) {
+	if _, err := s.ShowOrder(ctx, admin, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.AdminActionRepository.Find(ctx, orderID)
+}
+
+func (s *AdminServiceImpl) CancelOrder(ctx context.Context, admin *common.UserPayload, orderID int64, note string) (*entity.Order, error) {
+	var cancelledOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		cancelledOrder, err = s.OrderService.ForceCancel(ctx, int64(admin.ID), orderID, note)
+		if err != nil {
+			return err
+		}
+
+		return s.audit(ctx, admin, &entity.AdminAction{
+			Action:  constant.AdminCancelOrder,
+			OrderID: orderID,
+			Details: note,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return cancelledOrder, nil
+}
+
+func (s *AdminServiceImpl) CompleteOrder(ctx context.Context, admin *common.UserPayload, orderID int64, note string) (*entity.Order, error) {
+	var completedOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		completedOrder, err = s.OrderService.ForceComplete(ctx, int64(admin.ID), orderID, note)
+		if err != nil {
+			return err
+		}
+
+		return s.audit(ctx, admin, &entity.AdminAction{
+			Action:  constant.AdminCompleteOrder,
+			OrderID: orderID,
+			Details: note,
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return completedOrder, nil
+}
+
+// ShowTicket returns the orders holding the ticket and the size of its
+// waitlist.
+func (s *AdminServiceImpl) ShowTicket(ctx context.Context, admin *common.UserPayload, ticketID int64) (*entity.TicketReservation, error) {
+	ticket, err := s.TicketRepository.FindOne(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.OrderRepository.FindReserved(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	waiting, err := s.WaitlistRepository.CountWaiting(ctx, ticket.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.audit(ctx, admin, &entity.AdminAction{
+		Action:   constant.AdminViewTicket,
+		TicketID: ticket.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &entity.TicketReservation{
+		Ticket:  ticket,
+		Orders:  orders,
+		Waiting: waiting,
+	}, nil
+}
+
+func (s *AdminServiceImpl) audit(ctx context.Context, admin *common.UserPayload, action *entity.AdminAction) error {
+	action.AdminID = int64(admin.ID)
+	action.AdminEmail = admin.Email
+	action.CreatedAt = s.clock.Now().UTC()
+
+	_, err := s.AdminActionRepository.Insert(ctx, action)
+	return err
+}
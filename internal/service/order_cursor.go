@@ -0,0 +1,71 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+)
+
+const (
+	defaultOrderPageSize = 20
+	defaultOrderSort     = "id"
+)
+
+var orderSortColumns = map[string]string{
+	"id":        repository.OrderSortID,
+	"createdAt": repository.OrderSortCreatedAt,
+	"expiresAt": repository.OrderSortExpiresAt,
+}
+
+// orderCursor remembers the sort it was issued for, so a cursor cannot be
+// replayed against a different ordering.
+type orderCursor struct {
+	Sort string `json:"s"`
+	repository.OrderCursor
+}
+
+func encodeOrderCursor(sort string, order *entity.Order) string {
+	cursor := orderCursor{Sort: sort}
+	cursor.ID = order.ID
+	switch orderSortColumns[strings.TrimPrefix(sort, "-")] {
+	case repository.OrderSortCreatedAt:
+		cursor.SortValue = order.CreatedAt
+	case repository.OrderSortExpiresAt:
+		cursor.SortValue = order.ExpiresAt
+	}
+
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeOrderCursor(sort, value string) (*repository.OrderCursor, error) {
+	const op = "OrderServiceImpl.decodeOrderCursor"
+	invalidCursor := func(err error) error {
+		return &common.Error{
+			Code:    common.EINVALID,
+			Op:      op,
+			Message: "Invalid cursor",
+			Err:     err,
+		}
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, invalidCursor(err)
+	}
+
+	cursor := new(orderCursor)
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, invalidCursor(err)
+	}
+	if cursor.Sort != sort {
+		return nil, invalidCursor(errors.New("cursor was issued for a different sort"))
+	}
+
+	return &cursor.OrderCursor, nil
+}
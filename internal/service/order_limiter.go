@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/config"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Rules reported in the rejections counter of OrderLimiter.
+const (
+	ruleActiveOrders       = "active_orders"
+	ruleOrdersPerTitle     = "orders_per_title"
+	ruleExpirationCooldown = "expiration_cooldown"
+)
+
+// OrderLimiter enforces config.Limits on new orders and counts the orders it
+// rejects by rule.
+type OrderLimiter struct {
+	repository.OrderRepository
+	limits     *config.Limits
+	rejections *prometheus.CounterVec
+}
+
+func NewOrderLimiter(orderRepo repository.OrderRepository, limits *config.Limits, rejections *prometheus.CounterVec) *OrderLimiter {
+	return &OrderLimiter{
+		OrderRepository: orderRepo,
+		limits:          limits,
+		rejections:      rejections,
+	}
+}
+
+// Check tells whether userID may place an order for tickets with the given
+// titles at now. It must run inside the transaction inserting the order.
+func (l *OrderLimiter) Check(ctx context.Context, userID int64, titles []string, now time.Time) error {
+	const op = "OrderLimiter.Check"
+	if err := l.OrderRepository.LockUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if max := l.limits.MaxActiveOrders; max > 0 {
+		count, err := l.OrderRepository.CountActive(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if count >= max {
+			return l.reject(ruleActiveOrders, &common.Error{
+				Op:      op,
+				Code:    constant.EACTIVELIMIT,
+				Message: fmt.Sprintf("You can hold at most %d unpaid orders", max),
+				Err:     fmt.Errorf("user %d has %d active orders", userID, count),
+			})
+		}
+	}
+
+	if max := l.limits.MaxOrdersPerTitle; max > 0 {
+		for _, title := range titles {
+			count, err := l.OrderRepository.CountByTitle(ctx, userID, title)
+			if err != nil {
+				return err
+			}
+			if count >= max {
+				return l.reject(ruleOrdersPerTitle, &common.Error{
+					Op:      op,
+					Code:    constant.EPURCHASELIMIT,
+					Message: fmt.Sprintf("You can hold at most %d orders for tickets titled %q", max, title),
+					Err:     fmt.Errorf("user %d has %d orders for %q", userID, count, title),
+				})
+			}
+		}
+	}
+
+	if cooldown := l.limits.Cooldown; cooldown.Expirations > 0 {
+		count, latest, err := l.OrderRepository.CountExpired(ctx, userID, now.Add(-cooldown.Window))
+		if err != nil {
+			return err
+		}
+		if until := latest.Add(cooldown.Duration); count >= cooldown.Expirations && now.Before(until) {
+			return l.reject(ruleExpirationCooldown, &common.Error{
+				Op:      op,
+				Code:    constant.ECOOLDOWN,
+				Message: fmt.Sprintf("Too many orders expired, try again after %s", until.UTC().Format(time.RFC3339)),
+				Err:     fmt.Errorf("user %d let %d orders expire", userID, count),
+			})
+		}
+	}
+
+	return nil
+}
+
+func (l *OrderLimiter) reject(rule string, err error) error {
+	l.rejections.WithLabelValues(rule).Inc()
+	return err
+}
+
+// isLimitError tells whether err comes from OrderLimiter.
+func isLimitError(err error) bool {
+	switch common.ErrorCode(err) {
+	case constant.EACTIVELIMIT, constant.EPURCHASELIMIT, constant.ECOOLDOWN:
+		return true
+	}
+
+	return false
+}
@@ -1,10 +1,24 @@
 package service
 
-import "github.com/muktiarafi/ticketing-orders/internal/entity"
+import (
+	"context"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+)
 
 type OrderService interface {
-	Create(userID int64, ticketID int64) (*entity.Order, error)
-	Find(userID int64) ([]*entity.Order, error)
-	Show(userID, orderID int64) (*entity.Order, error)
-	Update(userID, orderID int64) (*entity.Order, error)
+	Create(ctx context.Context, userID int64, orderDTO *model.OrderDTO) (*entity.Order, error)
+	Find(ctx context.Context, userID int64, query *model.OrderQuery) ([]*entity.Order, string, error)
+	Show(ctx context.Context, userID, orderID int64) (*entity.Order, error)
+	Cancel(ctx context.Context, userID, orderID, version int64, cancellation *entity.Cancellation) (*entity.Order, error)
+	Patch(ctx context.Context, userID, orderID, version int64, patch *model.OrderPatchDTO) (*entity.Order, error)
+	Expire(ctx context.Context, orderID int64, sourceEventID string) (*entity.Order, error)
+	Complete(ctx context.Context, orderID int64, sourceEventID string) (*entity.Order, error)
+	Pay(ctx context.Context, payment *entity.Payment, sourceEventID string) (*entity.Order, error)
+	RequestRefund(ctx context.Context, userID, orderID, version int64, cancellation *entity.Cancellation) (*entity.Order, error)
+	Refund(ctx context.Context, orderID int64, sourceEventID string) (*entity.Order, error)
+	History(ctx context.Context, userID, orderID int64) ([]*entity.OrderEvent, error)
+	ForceCancel(ctx context.Context, adminID, orderID int64, note string) (*entity.Order, error)
+	ForceComplete(ctx context.Context, adminID, orderID int64, note string) (*entity.Order, error)
 }
@@ -1,82 +1,234 @@
 package service
 
 import (
-	"database/sql"
+	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/clock"
+	"github.com/muktiarafi/ticketing-orders/internal/config"
 	"github.com/muktiarafi/ticketing-orders/internal/constant"
 	"github.com/muktiarafi/ticketing-orders/internal/entity"
 	"github.com/muktiarafi/ticketing-orders/internal/events/producer"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
 	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/statemachine"
 )
 
 type OrderServiceImpl struct {
 	repository.OrderRepository
 	repository.TicketRepository
+	repository.OrderEventRepository
+	repository.WaitlistRepository
+	repository.PaymentRepository
 	producer.OrderProducer
+	producer.WaitlistProducer
+	repository.Transactor
+	*statemachine.Machine
+	*OrderLimiter
+	expiration *config.Expiration
+	clock      clock.Clock
 }
 
 func NewOrderService(
 	orderRepo repository.OrderRepository,
 	ticketRepo repository.TicketRepository,
+	orderEventRepo repository.OrderEventRepository,
+	waitlistRepo repository.WaitlistRepository,
+	paymentRepo repository.PaymentRepository,
 	orderProducer producer.OrderProducer,
+	waitlistProducer producer.WaitlistProducer,
+	transactor repository.Transactor,
+	orderMachine *statemachine.Machine,
+	orderLimiter *OrderLimiter,
+	expiration *config.Expiration,
+	clk clock.Clock,
 ) OrderService {
 	return &OrderServiceImpl{
-		OrderRepository:  orderRepo,
-		TicketRepository: ticketRepo,
-		OrderProducer:    orderProducer,
+		OrderRepository:      orderRepo,
+		TicketRepository:     ticketRepo,
+		OrderEventRepository: orderEventRepo,
+		WaitlistRepository:   waitlistRepo,
+		PaymentRepository:    paymentRepo,
+		OrderProducer:        orderProducer,
+		WaitlistProducer:     waitlistProducer,
+		Transactor:           transactor,
+		Machine:              orderMachine,
+		OrderLimiter:         orderLimiter,
+		expiration:           expiration,
+		clock:                clk,
 	}
 }
 
-func (s *OrderServiceImpl) Create(userID int64, ticketID int64) (*entity.Order, error) {
-	ticket, err := s.TicketRepository.FindOne(ticketID)
-	if err != nil {
-		return nil, err
-	}
-	orders, err := s.OrderRepository.FindReserved(ticket.ID)
-	er, ok := err.(*common.Error)
-	if ok {
-		if er.Err != sql.ErrNoRows {
-			return nil, err
+// Create reserves every ticket of the order at once. Tickets are locked in id
+// order so that two orders sharing tickets cannot deadlock, and the order
+// expires with the shortest window of its tickets. All tickets must be priced
+// in the same currency, and in orderDTO.Currency when it is given. The order
+// must also fit the user's limits.
+func (s *OrderServiceImpl) Create(ctx context.Context, userID int64, orderDTO *model.OrderDTO) (*entity.Order, error) {
+	return s.create(ctx, userID, orderDTO, &entity.OrderEvent{
+		ActorType: constant.ActorUser,
+		ActorID:   userID,
+		Reason:    "Order placed",
+	})
+}
+
+// create places the order for userID, recording change as the creation in the
+// order history.
+func (s *OrderServiceImpl) create(ctx context.Context, userID int64, orderDTO *model.OrderDTO, change *entity.OrderEvent) (*entity.Order, error) {
+	const op = "OrderServiceImpl.Create"
+	lineItems := orderDTO.LineItems()
+	sort.Slice(lineItems, func(i, j int) bool {
+		return lineItems[i].TicketID < lineItems[j].TicketID
+	})
+	for i := 1; i < len(lineItems); i++ {
+		if lineItems[i].TicketID == lineItems[i-1].TicketID {
+			return nil, &common.Error{
+				Op:      op,
+				Code:    common.EINVALID,
+				Message: "Ticket is ordered more than once",
+				Err:     errors.New("duplicate ticket in order items"),
+			}
 		}
 	}
-	if len(orders) != 0 {
-		return nil, &common.Error{
-			Op:      "OrderServiceImpl.Create",
-			Code:    common.EINVALID,
-			Message: "Ticket is already reserved",
-			Err:     errors.New("trying to create order with reserved ticket"),
+
+	var newOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := s.clock.Now().UTC()
+		order := &entity.Order{
+			Status:    constant.CREATED,
+			UserID:    userID,
+			CreatedAt: now,
+			Total:     money.New(0, orderDTO.Currency),
+			Items:     make([]*entity.OrderItem, 0, len(lineItems)),
 		}
 
-	}
+		var expiration time.Duration
+		for _, lineItem := range lineItems {
+			ticket, err := s.TicketRepository.FindOneForUpdate(ctx, lineItem.TicketID)
+			if err != nil {
+				return err
+			}
 
-	newOrder := &entity.Order{
-		Status:    constant.CREATED,
-		UserID:    userID,
-		Ticket:    ticket,
-		ExpiresAt: time.Now().Add(time.Second * 60),
-	}
+			orders, err := s.OrderRepository.FindReserved(ctx, ticket.ID)
+			if err != nil {
+				return err
+			}
+			if len(orders) != 0 {
+				return &common.Error{
+					Op:      op,
+					Code:    common.EINVALID,
+					Message: "Ticket is already reserved",
+					Err:     errors.New("trying to create order with reserved ticket"),
+				}
+			}
 
-	newOrder, err = s.OrderRepository.Insert(newOrder)
-	if err != nil {
-		return nil, err
-	}
+			if order.Total.Currency == "" {
+				order.Total.Currency = ticket.Price.Currency
+			}
+			order.Total, err = order.Total.Add(ticket.Price.Mul(int64(lineItem.Quantity)))
+			if err != nil {
+				return &common.Error{
+					Op:      op,
+					Code:    common.EINVALID,
+					Message: "Ticket prices do not match the order currency",
+					Err:     err,
+				}
+			}
+
+			ticketExpiration := s.expiration.For(ticket.ID, ticket.Price)
+			if expiration == 0 || ticketExpiration < expiration {
+				expiration = ticketExpiration
+			}
+
+			order.Items = append(order.Items, &entity.OrderItem{
+				Title:     ticket.Title,
+				Quantity:  lineItem.Quantity,
+				UnitPrice: ticket.Price,
+				Ticket:    ticket,
+			})
+		}
+		order.ExpiresAt = now.Add(expiration)
 
-	if err := s.OrderProducer.Created(newOrder); err != nil {
+		titles := make([]string, 0, len(order.Items))
+		for _, item := range order.Items {
+			titles = append(titles, item.Title)
+		}
+		if err := s.OrderLimiter.Check(ctx, userID, titles, now); err != nil {
+			return err
+		}
+
+		var err error
+		newOrder, err = s.OrderRepository.Insert(ctx, order)
+		if err != nil {
+			return err
+		}
+
+		if err := s.recordEvent(ctx, newOrder, "", change); err != nil {
+			return err
+		}
+
+		return s.OrderProducer.Created(ctx, newOrder)
+	}); err != nil {
 		return nil, err
 	}
 
 	return newOrder, nil
 }
 
-func (s *OrderServiceImpl) GetAll(userID int64) ([]*entity.Order, error) {
-	return s.OrderRepository.Find(userID)
+// Find returns one page of the user's orders together with the cursor of the
+// next page, which is empty on the last page. A zero userID searches the
+// orders of every user.
+func (s *OrderServiceImpl) Find(ctx context.Context, userID int64, query *model.OrderQuery) ([]*entity.Order, string, error) {
+	orderSort := query.Sort
+	if orderSort == "" {
+		orderSort = defaultOrderSort
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultOrderPageSize
+	}
+
+	filter := &repository.OrderFilter{
+		UserID:      userID,
+		Statuses:    query.Status,
+		TicketID:    query.TicketID,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+		ExpiresFrom: query.ExpiresFrom,
+		ExpiresTo:   query.ExpiresTo,
+		SortBy:      orderSortColumns[strings.TrimPrefix(orderSort, "-")],
+		Descending:  strings.HasPrefix(orderSort, "-"),
+		Limit:       limit + 1,
+	}
+	if query.Cursor != "" {
+		after, err := decodeOrderCursor(orderSort, query.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.After = after
+	}
+
+	orders, err := s.OrderRepository.Find(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(orders) <= limit {
+		return orders, "", nil
+	}
+	orders = orders[:limit]
+
+	return orders, encodeOrderCursor(orderSort, orders[limit-1]), nil
 }
 
-func (s *OrderServiceImpl) Show(userID, orderID int64) (*entity.Order, error) {
-	order, err := s.OrderRepository.FindOne(orderID)
+func (s *OrderServiceImpl) Show(ctx context.Context, userID, orderID int64) (*entity.Order, error) {
+	order, err := s.OrderRepository.FindOne(ctx, orderID)
 	if err != nil {
 		return nil, err
 	}
@@ -93,30 +245,407 @@ func (s *OrderServiceImpl) Show(userID, orderID int64) (*entity.Order, error) {
 	return order, nil
 }
 
-func (s *OrderServiceImpl) Update(userID, orderID int64) (*entity.Order, error) {
-	order, err := s.OrderRepository.FindOne(orderID)
+// Cancel cancels the order on behalf of its owner. version is the order
+// version the client last saw; when it is not zero and the order has changed
+// since, nothing is written.
+func (s *OrderServiceImpl) Cancel(ctx context.Context, userID, orderID, version int64, cancellation *entity.Cancellation) (*entity.Order, error) {
+	var cancelledOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.findOwned(ctx, "OrderServiceImpl.Cancel", userID, orderID, version)
+		if err != nil {
+			return err
+		}
+
+		order.Cancellation = cancellation
+		cancelledOrder, err = s.changeStatus(ctx, order, constant.CANCELLED, &entity.OrderEvent{
+			ActorType: constant.ActorUser,
+			ActorID:   userID,
+			Reason:    cancellationReason(cancellation),
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.release(ctx, cancelledOrder)
+	}); err != nil {
+		return nil, err
+	}
+
+	return cancelledOrder, nil
+}
+
+// RequestRefund starts the refund of a paid order. The tickets stay reserved
+// until Refund confirms the money was returned.
+func (s *OrderServiceImpl) RequestRefund(ctx context.Context, userID, orderID, version int64, cancellation *entity.Cancellation) (*entity.Order, error) {
+	var refundedOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.findOwned(ctx, "OrderServiceImpl.RequestRefund", userID, orderID, version)
+		if err != nil {
+			return err
+		}
+
+		order.Cancellation = cancellation
+		refundedOrder, err = s.changeStatus(ctx, order, constant.REFUND_REQUESTED, &entity.OrderEvent{
+			ActorType: constant.ActorUser,
+			ActorID:   userID,
+			Reason:    cancellationReason(cancellation),
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.OrderProducer.RefundRequested(ctx, refundedOrder)
+	}); err != nil {
+		return nil, err
+	}
+
+	return refundedOrder, nil
+}
+
+// Refund completes a requested refund and releases the tickets of the order.
+func (s *OrderServiceImpl) Refund(ctx context.Context, orderID int64, sourceEventID string) (*entity.Order, error) {
+	var refundedOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.OrderRepository.FindOne(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		refundedOrder, err = s.changeStatus(ctx, order, constant.REFUNDED, &entity.OrderEvent{
+			ActorType:     constant.ActorPayment,
+			Reason:        "Refund completed",
+			SourceEventID: sourceEventID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.release(ctx, refundedOrder)
+	}); err != nil {
+		return nil, err
+	}
+
+	return refundedOrder, nil
+}
+
+// Patch applies the fields set in patch. An empty patch returns the order
+// unchanged.
+func (s *OrderServiceImpl) Patch(ctx context.Context, userID, orderID, version int64, patch *model.OrderPatchDTO) (*entity.Order, error) {
+	if patch.Status != nil {
+		return s.Cancel(ctx, userID, orderID, version, &entity.Cancellation{
+			ReasonCode: patch.Cancellation.ReasonCode,
+			Note:       patch.Cancellation.Note,
+		})
+	}
+
+	return s.findOwned(ctx, "OrderServiceImpl.Patch", userID, orderID, version)
+}
+
+// findOwned loads an order of the user, checking it is still at version when
+// version is not zero.
+func (s *OrderServiceImpl) findOwned(ctx context.Context, op string, userID, orderID, version int64) (*entity.Order, error) {
+	order, err := s.OrderRepository.FindOne(ctx, orderID)
 	if err != nil {
 		return nil, err
 	}
 
 	if order.UserID != userID {
 		return nil, &common.Error{
-			Op:      "OrderServiceImpl.Update",
+			Op:      op,
 			Code:    common.EINVALID,
 			Message: "Not Authorized",
 			Err:     errors.New("trying to access order not belonged to"),
 		}
 	}
-	order.Status = constant.CANCELLED
+	if version != 0 && order.Version != version {
+		return nil, &common.Error{
+			Op:      op,
+			Code:    constant.EPRECONDITION,
+			Message: "Order has been modified since it was last read",
+			Err:     fmt.Errorf("expected order version %d but found %d", version, order.Version),
+		}
+	}
+
+	return order, nil
+}
+
+func cancellationReason(cancellation *entity.Cancellation) string {
+	if cancellation.Note == "" {
+		return cancellation.ReasonCode
+	}
+
+	return cancellation.ReasonCode + ": " + cancellation.Note
+}
+
+// Expire cancels an order whose reservation window has passed. Orders that
+// can no longer be cancelled, e.g. already completed ones, are returned
+// unchanged. sourceEventID is empty when the expiration was not triggered by
+// an event.
+func (s *OrderServiceImpl) Expire(ctx context.Context, orderID int64, sourceEventID string) (*entity.Order, error) {
+	var expiredOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.OrderRepository.FindOne(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if !s.Machine.Can(order.Status, constant.CANCELLED) {
+			expiredOrder = order
+			return nil
+		}
+
+		order.Cancellation = &entity.Cancellation{ReasonCode: constant.ReasonExpired}
+		expiredOrder, err = s.changeStatus(ctx, order, constant.CANCELLED, &entity.OrderEvent{
+			ActorType:     constant.ActorExpiration,
+			Reason:        constant.ReasonExpired,
+			SourceEventID: sourceEventID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.release(ctx, expiredOrder)
+	}); err != nil {
+		return nil, err
+	}
+
+	return expiredOrder, nil
+}
+
+// Complete marks an order as paid.
+func (s *OrderServiceImpl) Complete(ctx context.Context, orderID int64, sourceEventID string) (*entity.Order, error) {
+	var completedOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.OrderRepository.FindOne(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		completedOrder, err = s.changeStatus(ctx, order, constant.COMPLETED, &entity.OrderEvent{
+			ActorType:     constant.ActorPayment,
+			Reason:        "Payment received",
+			SourceEventID: sourceEventID,
+		})
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return completedOrder, nil
+}
+
+// Pay records a payment received for an order and completes the order. A
+// payment for an unknown order, or for one that can no longer be completed,
+// e.g. cancelled by expiry or paid already, is recorded as mismatched and
+// flagged for a refund instead; the order returned is then nil for an
+// unknown order. A payment seen before returns the order unchanged.
+func (s *OrderServiceImpl) Pay(ctx context.Context, payment *entity.Payment, sourceEventID string) (*entity.Order, error) {
+	var paidOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.OrderRepository.FindOne(ctx, payment.OrderID)
+		if err != nil && common.ErrorCode(err) != common.ENOTFOUND {
+			return err
+		}
+
+		payment.Status = constant.PAYMENT_ACCEPTED
+		payment.Reason = ""
+		payment.CreatedAt = s.clock.Now().UTC()
+		switch {
+		case order == nil:
+			payment.Status = constant.PAYMENT_MISMATCHED
+			payment.Reason = "order not found"
+		case !s.Machine.Can(order.Status, constant.COMPLETED):
+			payment.Status = constant.PAYMENT_MISMATCHED
+			payment.Reason = fmt.Sprintf("order is %s", order.Status)
+		}
+
+		inserted, err := s.PaymentRepository.Insert(ctx, payment)
+		if err != nil {
+			return err
+		}
+
+		paidOrder = order
+		if !inserted {
+			return nil
+		}
+
+		if payment.Status == constant.PAYMENT_MISMATCHED {
+			return s.OrderProducer.PaymentMismatch(ctx, order, payment)
+		}
+
+		paidOrder, err = s.changeStatus(ctx, order, constant.COMPLETED, &entity.OrderEvent{
+			ActorType:     constant.ActorPayment,
+			Reason:        "Payment received",
+			SourceEventID: sourceEventID,
+		})
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return paidOrder, nil
+}
+
+// release publishes the cancellation of the order and hands each of its
+// tickets to the next user waiting for it. It must run inside a transaction.
+func (s *OrderServiceImpl) release(ctx context.Context, order *entity.Order) error {
+	if err := s.OrderProducer.Cancelled(ctx, order); err != nil {
+		return err
+	}
+
+	for _, item := range order.Items {
+		if err := s.promote(ctx, item.Ticket.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// promote creates an order for the first waiting user of the ticket. Users
+// who cannot order the ticket are skipped in favour of the next one.
+func (s *OrderServiceImpl) promote(ctx context.Context, ticketID int64) error {
+	for {
+		entry, err := s.WaitlistRepository.FindNext(ctx, ticketID)
+		if err != nil {
+			if common.ErrorCode(err) == common.ENOTFOUND {
+				return nil
+			}
+			return err
+		}
+
+		order, err := s.create(ctx, entry.UserID, &model.OrderDTO{TicketID: ticketID}, &entity.OrderEvent{
+			ActorType: constant.ActorWaitlist,
+			ActorID:   entry.UserID,
+			Reason:    "Promoted from waitlist",
+		})
+		if err != nil {
+			if common.ErrorCode(err) != common.EINVALID && !isLimitError(err) {
+				return err
+			}
+
+			entry.Status = constant.WAITLIST_SKIPPED
+			if err := s.WaitlistRepository.UpdateStatus(ctx, entry); err != nil {
+				return err
+			}
+			continue
+		}
+
+		entry.Status = constant.WAITLIST_PROMOTED
+		entry.OrderID = order.ID
+		if err := s.WaitlistRepository.UpdateStatus(ctx, entry); err != nil {
+			return err
+		}
+
+		return s.WaitlistProducer.Promoted(ctx, entry, order)
+	}
+}
+
+// ForceCancel cancels any order on behalf of an admin, releasing its tickets.
+func (s *OrderServiceImpl) ForceCancel(ctx context.Context, adminID, orderID int64, note string) (*entity.Order, error) {
+	var cancelledOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.OrderRepository.FindOne(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		order.Cancellation = &entity.Cancellation{
+			ReasonCode: constant.ReasonCancelledByAdmin,
+			Note:       note,
+		}
+		cancelledOrder, err = s.changeStatus(ctx, order, constant.CANCELLED, &entity.OrderEvent{
+			ActorType: constant.ActorAdmin,
+			ActorID:   adminID,
+			Reason:    cancellationReason(order.Cancellation),
+		})
+		if err != nil {
+			return err
+		}
 
-	updatedOrder, err := s.OrderRepository.Update(order)
+		return s.release(ctx, cancelledOrder)
+	}); err != nil {
+		return nil, err
+	}
+
+	return cancelledOrder, nil
+}
+
+// ForceComplete marks any order as paid on behalf of an admin, e.g. when the
+// payment was settled outside the payments service.
+func (s *OrderServiceImpl) ForceComplete(ctx context.Context, adminID, orderID int64, note string) (*entity.Order, error) {
+	var completedOrder *entity.Order
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		order, err := s.OrderRepository.FindOne(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		completedOrder, err = s.changeStatus(ctx, order, constant.COMPLETED, &entity.OrderEvent{
+			ActorType: constant.ActorAdmin,
+			ActorID:   adminID,
+			Reason:    note,
+		})
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return completedOrder, nil
+}
+
+// History returns the status changes of an order to its owner.
+func (s *OrderServiceImpl) History(ctx context.Context, userID, orderID int64) ([]*entity.OrderEvent, error) {
+	if _, err := s.Show(ctx, userID, orderID); err != nil {
+		return nil, err
+	}
+
+	return s.OrderEventRepository.Find(ctx, orderID)
+}
+
+// changeStatus moves the order to status to and records the change in the
+// order history. change carries who made the change and why. Completed orders
+// are also announced with an OrderCompleted event. It must run inside a
+// transaction.
+func (s *OrderServiceImpl) changeStatus(ctx context.Context, order *entity.Order, to string, change *entity.OrderEvent) (*entity.Order, error) {
+	from := order.Status
+	if err := s.Machine.Transition(order, to); err != nil {
+		return nil, err
+	}
+
+	updatedOrder, err := s.OrderRepository.Update(ctx, order)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.OrderProducer.Cancelled(updatedOrder); err != nil {
+	if err := s.recordEvent(ctx, updatedOrder, from, change); err != nil {
 		return nil, err
 	}
 
+	if to == constant.COMPLETED {
+		if err := s.OrderProducer.Completed(ctx, updatedOrder); err != nil {
+			return nil, err
+		}
+	}
+
 	return updatedOrder, nil
 }
+
+// recordEvent adds the change to the order history and publishes the new
+// state of the order with it.
+func (s *OrderServiceImpl) recordEvent(ctx context.Context, order *entity.Order, from string, change *entity.OrderEvent) error {
+	event := *change
+	event.OrderID = order.ID
+	event.FromStatus = from
+	event.ToStatus = order.Status
+	event.CreatedAt = s.clock.Now().UTC()
+
+	if _, err := s.OrderEventRepository.Insert(ctx, &event); err != nil {
+		return err
+	}
+
+	return s.OrderProducer.Updated(ctx, order, &event)
+}
@@ -0,0 +1,31 @@
+package service_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/testutil"
+)
+
+var ticketRepo repository.TicketRepository
+var transactor repository.Transactor
+var db *driver.DB
+
+var testClock = testutil.FixedClock(time.Now().UTC().Truncate(time.Second))
+
+func TestMain(m *testing.M) {
+	var purge func()
+	db, purge = testutil.NewDatabase()
+
+	ticketRepo = repository.NewTicketRepository(db)
+	transactor = repository.NewTransactor(db)
+
+	code := m.Run()
+
+	purge()
+
+	os.Exit(code)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type TicketService interface {
+	Create(ctx context.Context, ticket *entity.Ticket) error
+	Update(ctx context.Context, ticket *entity.Ticket) error
+	Resync(ctx context.Context, ticketID int64) error
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/clock"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/events/producer"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+)
+
+// TicketServiceImpl keeps the local copy of tickets in step with the tickets
+// service. Updates arriving ahead of their predecessor are parked until the
+// gap is filled; Resync gives up waiting on a gap.
+type TicketServiceImpl struct {
+	repository.TicketRepository
+	repository.ParkedTicketUpdateRepository
+	producer.TicketProducer
+	repository.Transactor
+	clock clock.Clock
+}
+
+func NewTicketService(
+	ticketRepo repository.TicketRepository,
+	parkedTicketUpdateRepo repository.ParkedTicketUpdateRepository,
+	ticketProducer producer.TicketProducer,
+	transactor repository.Transactor,
+	clk clock.Clock,
+) TicketService {
+	return &TicketServiceImpl{
+		TicketRepository:             ticketRepo,
+		ParkedTicketUpdateRepository: parkedTicketUpdateRepo,
+		TicketProducer:               ticketProducer,
+		Transactor:                   transactor,
+		clock:                        clk,
+	}
+}
+
+// Create stores a new ticket and applies the updates parked for it. A ticket
+// already restored by Resync at the same or a later version is left alone.
+func (s *TicketServiceImpl) Create(ctx context.Context, ticket *entity.Ticket) error {
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.TicketRepository.FindOneForUpdate(ctx, ticket.ID)
+		switch {
+		case err == nil && ticket.Version <= current.Version:
+			log.Printf("ignoring creation of ticket %d, already at version %d", ticket.ID, current.Version)
+			return nil
+		case err != nil && common.ErrorCode(err) != common.ENOTFOUND:
+			return err
+		}
+
+		newTicket, err := s.TicketRepository.Insert(ctx, ticket)
+		if err != nil {
+			return err
+		}
+
+		return s.applyParked(ctx, newTicket)
+	})
+}
+
+// Update applies the next version of a ticket along with the updates parked
+// behind it. Versions already applied are ignored, and versions ahead of the
+// next one, or of a ticket not created yet, are parked.
+func (s *TicketServiceImpl) Update(ctx context.Context, ticket *entity.Ticket) error {
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.TicketRepository.FindOneForUpdate(ctx, ticket.ID)
+		if err != nil {
+			if common.ErrorCode(err) == common.ENOTFOUND {
+				return s.park(ctx, ticket)
+			}
+			return err
+		}
+
+		switch {
+		case ticket.Version <= current.Version:
+			log.Printf("ignoring ticket %d version %d, already at %d", ticket.ID, ticket.Version, current.Version)
+			return nil
+		case ticket.Version > current.Version+1:
+			return s.park(ctx, ticket)
+		}
+
+		updatedTicket, err := s.TicketRepository.UpdateByEvent(ctx, ticket)
+		if err != nil {
+			return err
+		}
+
+		return s.applyParked(ctx, updatedTicket)
+	})
+}
+
+// Resync fast-forwards the ticket to its latest parked update, skipping the
+// versions that never arrived, and asks the tickets service to publish the
+// ticket again.
+func (s *TicketServiceImpl) Resync(ctx context.Context, ticketID int64) error {
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// versions start at 1, so a ticket never created misses them all
+		missingFrom := int64(1)
+		current, err := s.TicketRepository.FindOneForUpdate(ctx, ticketID)
+		switch {
+		case err == nil:
+			missingFrom = current.Version + 1
+		case common.ErrorCode(err) != common.ENOTFOUND:
+			return err
+		}
+
+		latest, err := s.ParkedTicketUpdateRepository.FindLatest(ctx, ticketID)
+		if err != nil {
+			if common.ErrorCode(err) == common.ENOTFOUND {
+				return nil
+			}
+			return err
+		}
+
+		if err := s.ParkedTicketUpdateRepository.Delete(ctx, ticketID, latest.Version); err != nil {
+			return err
+		}
+		if current != nil && latest.Version <= current.Version {
+			return nil
+		}
+
+		if _, err := s.TicketRepository.Restore(ctx, latest.Ticket); err != nil {
+			return err
+		}
+		log.Printf("resynced ticket %d to version %d, versions %d to %d are missing", ticketID, latest.Version, missingFrom, latest.Version-1)
+
+		return s.TicketProducer.ResyncRequested(ctx, ticketID, missingFrom, latest.Version)
+	})
+}
+
+func (s *TicketServiceImpl) park(ctx context.Context, ticket *entity.Ticket) error {
+	log.Printf("parking ticket %d version %d until its predecessor arrives", ticket.ID, ticket.Version)
+	return s.ParkedTicketUpdateRepository.Insert(ctx, &entity.ParkedTicketUpdate{
+		Ticket:   ticket,
+		ParkedAt: s.clock.Now().UTC(),
+	})
+}
+
+// applyParked applies the parked updates following current in order, then
+// drops the parked updates it made obsolete.
+func (s *TicketServiceImpl) applyParked(ctx context.Context, current *entity.Ticket) error {
+	for {
+		next, err := s.ParkedTicketUpdateRepository.FindOne(ctx, current.ID, current.Version+1)
+		if err != nil {
+			if common.ErrorCode(err) == common.ENOTFOUND {
+				break
+			}
+			return err
+		}
+
+		current, err = s.TicketRepository.UpdateByEvent(ctx, next.Ticket)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.ParkedTicketUpdateRepository.Delete(ctx, current.ID, current.Version)
+}
@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/events/outbox"
+	"github.com/muktiarafi/ticketing-orders/internal/events/producer"
+	"github.com/muktiarafi/ticketing-orders/internal/events/schema"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+	"github.com/muktiarafi/ticketing-orders/internal/testutil"
+)
+
+func TestTicketServiceResync(t *testing.T) {
+	ticketService := service.NewTicketService(
+		ticketRepo,
+		repository.NewParkedTicketUpdateRepository(db),
+		producer.NewTicketProducer(outbox.NewPublisher(repository.NewOutboxRepository(db))),
+		transactor,
+		testClock,
+	)
+
+	t.Run("resync of a ticket never created", func(t *testing.T) {
+		ticketID := testutil.NextTicketID()
+		parked := &entity.Ticket{ID: ticketID, Title: "v3", Price: money.New(1200, "USD"), Version: 3}
+		if err := ticketService.Update(context.Background(), parked); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ticketService.Resync(context.Background(), ticketID); err != nil {
+			t.Fatal(err)
+		}
+
+		ticket, err := ticketRepo.FindOne(context.Background(), ticketID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ticket.Version != 3 || ticket.Title != "v3" {
+			t.Errorf("expecting ticket at version 3 titled v3, got version %d titled %q", ticket.Version, ticket.Title)
+		}
+
+		var payload []byte
+		stmt := `SELECT payload FROM outbox WHERE topic = $1 ORDER BY id DESC LIMIT 1`
+		if err := db.SQL.QueryRow(stmt, schema.TicketResyncRequested).Scan(&payload); err != nil {
+			t.Fatal(err)
+		}
+		resyncRequestedData := new(schema.TicketResyncRequestedEvent)
+		if err := resyncRequestedData.Unmarshal(payload); err != nil {
+			t.Fatal(err)
+		}
+		if resyncRequestedData.TicketID != ticketID || resyncRequestedData.MissingFrom != 1 || resyncRequestedData.MissingTo != 3 {
+			t.Errorf("expecting versions 1 up to 3 of ticket %d to be requested, got %d up to %d of ticket %d",
+				ticketID, resyncRequestedData.MissingFrom, resyncRequestedData.MissingTo, resyncRequestedData.TicketID)
+		}
+	})
+}
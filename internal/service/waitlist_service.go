@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+type WaitlistService interface {
+	Join(ctx context.Context, userID, ticketID int64) (*entity.WaitlistEntry, error)
+	Show(ctx context.Context, userID, ticketID int64) (*entity.WaitlistEntry, error)
+	Leave(ctx context.Context, userID, ticketID int64) error
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/clock"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+)
+
+type WaitlistServiceImpl struct {
+	repository.WaitlistRepository
+	repository.TicketRepository
+	repository.OrderRepository
+	repository.Transactor
+	clock clock.Clock
+}
+
+func NewWaitlistService(
+	waitlistRepo repository.WaitlistRepository,
+	ticketRepo repository.TicketRepository,
+	orderRepo repository.OrderRepository,
+	transactor repository.Transactor,
+	clk clock.Clock,
+) WaitlistService {
+	return &WaitlistServiceImpl{
+		WaitlistRepository: waitlistRepo,
+		TicketRepository:   ticketRepo,
+		OrderRepository:    orderRepo,
+		Transactor:         transactor,
+		clock:              clk,
+	}
+}
+
+// Join queues the user for a ticket that is currently reserved. Available
+// tickets should be ordered directly instead. The ticket is locked while
+// checking its reservations, so a concurrent order or cancellation cannot
+// change them before the user is queued.
+func (s *WaitlistServiceImpl) Join(ctx context.Context, userID, ticketID int64) (*entity.WaitlistEntry, error) {
+	const op = "WaitlistServiceImpl.Join"
+	var newEntry *entity.WaitlistEntry
+	if err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		ticket, err := s.TicketRepository.FindOneForUpdate(ctx, ticketID)
+		if err != nil {
+			return err
+		}
+
+		orders, err := s.OrderRepository.FindReserved(ctx, ticket.ID)
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			return &common.Error{
+				Op:      op,
+				Code:    common.EINVALID,
+				Message: "Ticket is available, order it instead",
+				Err:     errors.New("trying to wait for an available ticket"),
+			}
+		}
+		for _, order := range orders {
+			if order.UserID == userID {
+				return &common.Error{
+					Op:      op,
+					Code:    common.EINVALID,
+					Message: "Ticket is already reserved by you",
+					Err:     errors.New("trying to wait for a ticket reserved by the same user"),
+				}
+			}
+		}
+
+		newEntry, err = s.WaitlistRepository.Insert(ctx, &entity.WaitlistEntry{
+			TicketID:  ticket.ID,
+			UserID:    userID,
+			CreatedAt: s.clock.Now().UTC(),
+		})
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return newEntry, nil
+}
+
+// Show returns the user's place in the ticket's waitlist.
+func (s *WaitlistServiceImpl) Show(ctx context.Context, userID, ticketID int64) (*entity.WaitlistEntry, error) {
+	return s.WaitlistRepository.FindWaiting(ctx, ticketID, userID)
+}
+
+func (s *WaitlistServiceImpl) Leave(ctx context.Context, userID, ticketID int64) error {
+	entry, err := s.WaitlistRepository.FindWaiting(ctx, ticketID, userID)
+	if err != nil {
+		return err
+	}
+
+	entry.Status = constant.WAITLIST_LEFT
+	return s.WaitlistRepository.UpdateStatus(ctx, entry)
+}
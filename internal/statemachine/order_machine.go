@@ -0,0 +1,93 @@
+package statemachine
+
+import (
+	"errors"
+	"fmt"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+// Guard is consulted before an order is moved to a new status. Returning an
+// error aborts the transition.
+type Guard func(order *entity.Order, from, to string) error
+
+// Hook is called after an order status has been changed in memory. Returning
+// an error aborts the transition and restores the previous status.
+type Hook func(order *entity.Order, from, to string) error
+
+type Machine struct {
+	transitions map[string]map[string]bool
+	guards      map[string][]Guard
+	hooks       []Hook
+}
+
+func New() *Machine {
+	return &Machine{
+		transitions: make(map[string]map[string]bool),
+		guards:      make(map[string][]Guard),
+	}
+}
+
+// NewOrderMachine returns a machine preloaded with the order lifecycle.
+func NewOrderMachine() *Machine {
+	m := New()
+	m.Allow(constant.CREATED, constant.PENDING, constant.COMPLETED, constant.CANCELLED)
+	m.Allow(constant.PENDING, constant.COMPLETED, constant.CANCELLED)
+	m.Allow(constant.COMPLETED, constant.REFUND_REQUESTED)
+	m.Allow(constant.REFUND_REQUESTED, constant.REFUNDED)
+
+	return m
+}
+
+func (m *Machine) Allow(from string, to ...string) {
+	if m.transitions[from] == nil {
+		m.transitions[from] = make(map[string]bool)
+	}
+	for _, status := range to {
+		m.transitions[from][status] = true
+	}
+}
+
+// Guard registers a guard for every transition into the given status.
+func (m *Machine) Guard(to string, guard Guard) {
+	m.guards[to] = append(m.guards[to], guard)
+}
+
+func (m *Machine) OnTransition(hook Hook) {
+	m.hooks = append(m.hooks, hook)
+}
+
+func (m *Machine) Can(from, to string) bool {
+	return m.transitions[from][to]
+}
+
+func (m *Machine) Transition(order *entity.Order, to string) error {
+	const op = "Machine.Transition"
+	from := order.Status
+	if !m.Can(from, to) {
+		return &common.Error{
+			Code:    common.ECONCLICT,
+			Op:      op,
+			Message: fmt.Sprintf("Order cannot be changed from %s to %s", from, to),
+			Err:     errors.New("illegal order status transition"),
+		}
+	}
+
+	for _, guard := range m.guards[to] {
+		if err := guard(order, from, to); err != nil {
+			return &common.Error{Code: common.ECONCLICT, Op: op, Err: err}
+		}
+	}
+
+	order.Status = to
+	for _, hook := range m.hooks {
+		if err := hook(order, from, to); err != nil {
+			order.Status = from
+			return &common.Error{Op: op, Err: err}
+		}
+	}
+
+	return nil
+}
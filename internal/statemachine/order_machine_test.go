@@ -0,0 +1,131 @@
+package statemachine
+
+import (
+	"errors"
+	"testing"
+
+	common "github.com/muktiarafi/ticketing-common"
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+)
+
+var orderStatuses = []string{
+	constant.CREATED,
+	constant.PENDING,
+	constant.COMPLETED,
+	constant.CANCELLED,
+	constant.REFUND_REQUESTED,
+	constant.REFUNDED,
+}
+
+func TestOrderMachineTransition(t *testing.T) {
+	allowed := map[string]map[string]bool{
+		constant.CREATED: {
+			constant.PENDING:   true,
+			constant.COMPLETED: true,
+			constant.CANCELLED: true,
+		},
+		constant.PENDING: {
+			constant.COMPLETED: true,
+			constant.CANCELLED: true,
+		},
+		constant.COMPLETED: {
+			constant.REFUND_REQUESTED: true,
+		},
+		constant.REFUND_REQUESTED: {
+			constant.REFUNDED: true,
+		},
+	}
+
+	type transitionTest struct {
+		from, to string
+		allowed  bool
+	}
+	var tests []transitionTest
+	for _, from := range orderStatuses {
+		for _, to := range orderStatuses {
+			tests = append(tests, transitionTest{from, to, allowed[from][to]})
+		}
+	}
+
+	machine := NewOrderMachine()
+	for _, test := range tests {
+		test := test
+		t.Run(test.from+" to "+test.to, func(t *testing.T) {
+			order := &entity.Order{Status: test.from}
+
+			if got := machine.Can(test.from, test.to); got != test.allowed {
+				t.Errorf("expecting Can to report %v but got %v instead", test.allowed, got)
+			}
+
+			err := machine.Transition(order, test.to)
+			if test.allowed {
+				if err != nil {
+					t.Fatalf("expecting transition to succeed but got %v", err)
+				}
+				if order.Status != test.to {
+					t.Errorf("expecting status %s but got %s instead", test.to, order.Status)
+				}
+				return
+			}
+
+			if code := common.ErrorCode(err); code != common.ECONCLICT {
+				t.Errorf("expecting error code %s but got %q instead", common.ECONCLICT, code)
+			}
+			if order.Status != test.from {
+				t.Errorf("expecting status to stay %s but got %s instead", test.from, order.Status)
+			}
+		})
+	}
+}
+
+func TestMachineGuard(t *testing.T) {
+	machine := NewOrderMachine()
+	machine.Guard(constant.COMPLETED, func(order *entity.Order, from, to string) error {
+		if order.UserID == 0 {
+			return errors.New("order has no user")
+		}
+		return nil
+	})
+
+	order := &entity.Order{Status: constant.PENDING}
+	err := machine.Transition(order, constant.COMPLETED)
+	if code := common.ErrorCode(err); code != common.ECONCLICT {
+		t.Errorf("expecting error code %s but got %q instead", common.ECONCLICT, code)
+	}
+	if order.Status != constant.PENDING {
+		t.Errorf("expecting status to stay %s but got %s instead", constant.PENDING, order.Status)
+	}
+
+	if err := machine.Transition(order, constant.CANCELLED); err != nil {
+		t.Errorf("expecting guard to leave other transitions alone but got %v", err)
+	}
+}
+
+func TestMachineOnTransition(t *testing.T) {
+	machine := NewOrderMachine()
+	var seen []string
+	machine.OnTransition(func(order *entity.Order, from, to string) error {
+		seen = append(seen, from+" to "+to)
+		if to == constant.CANCELLED {
+			return errors.New("cannot release tickets")
+		}
+		return nil
+	})
+
+	order := &entity.Order{Status: constant.CREATED}
+	if err := machine.Transition(order, constant.PENDING); err != nil {
+		t.Fatal(err)
+	}
+	if err := machine.Transition(order, constant.CANCELLED); err == nil {
+		t.Error("expecting failing hook to abort the transition")
+	}
+	if order.Status != constant.PENDING {
+		t.Errorf("expecting status to be restored to %s but got %s instead", constant.PENDING, order.Status)
+	}
+
+	want := []string{"CREATED to PENDING", "PENDING to CANCELLED"}
+	if len(seen) != len(want) || seen[0] != want[0] || seen[1] != want[1] {
+		t.Errorf("expecting hook to see %v but got %v instead", want, seen)
+	}
+}
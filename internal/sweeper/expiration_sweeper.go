@@ -0,0 +1,108 @@
+package sweeper
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/clock"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+)
+
+// ExpirationSweeper is a fallback for the expiration service. It periodically
+// cancels CREATED orders that are past their expiry by more than grace,
+// through the same OrderService.Expire path used for ExpirationComplete
+// events. Each order is expired in its own transaction while its row is
+// locked, so running a sweeper on every replica is safe.
+type ExpirationSweeper struct {
+	repository.OrderRepository
+	repository.Transactor
+	service.OrderService
+	clock     clock.Clock
+	interval  time.Duration
+	grace     time.Duration
+	batchSize int
+}
+
+func NewExpirationSweeper(
+	orderRepo repository.OrderRepository,
+	transactor repository.Transactor,
+	orderService service.OrderService,
+	clk clock.Clock,
+	interval time.Duration,
+	grace time.Duration,
+	batchSize int,
+) *ExpirationSweeper {
+	return &ExpirationSweeper{
+		OrderRepository: orderRepo,
+		Transactor:      transactor,
+		OrderService:    orderService,
+		clock:           clk,
+		interval:        interval,
+		grace:           grace,
+		batchSize:       batchSize,
+	}
+}
+
+func (s *ExpirationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Sweep(ctx); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// Sweep expires up to batchSize overdue orders. An order that fails to expire
+// is logged and passed over, so it cannot hold back the ones behind it; it is
+// tried again on the next sweep.
+func (s *ExpirationSweeper) Sweep(ctx context.Context) error {
+	failed := make([]int64, 0)
+	for i := 0; i < s.batchSize; i++ {
+		orderID, err := s.expireNext(ctx, failed)
+		if err != nil {
+			if orderID == 0 {
+				return err
+			}
+			log.Printf("could not expire order %d: %v", orderID, err)
+			failed = append(failed, orderID)
+			continue
+		}
+		if orderID == 0 {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// expireNext expires the oldest overdue order not in skip and returns its
+// id, or zero when there is none.
+func (s *ExpirationSweeper) expireNext(ctx context.Context, skip []int64) (int64, error) {
+	var orderID int64
+	err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		deadline := s.clock.Now().UTC().Add(-s.grace)
+		orderIDs, err := s.OrderRepository.FindExpired(ctx, deadline, skip, 1)
+		if err != nil || len(orderIDs) == 0 {
+			return err
+		}
+
+		orderID = orderIDs[0]
+		if _, err := s.OrderService.Expire(ctx, orderID, ""); err != nil {
+			return err
+		}
+		log.Println("expired order by sweeper:", orderID)
+
+		return nil
+	})
+
+	return orderID, err
+}
@@ -0,0 +1,86 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/constant"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/model"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+	"github.com/muktiarafi/ticketing-orders/internal/testutil"
+)
+
+// failingExpiry fails to expire one order, standing in for an order that
+// keeps failing.
+type failingExpiry struct {
+	service.OrderService
+	orderID int64
+}
+
+func (s *failingExpiry) Expire(ctx context.Context, orderID int64, sourceEventID string) (*entity.Order, error) {
+	if orderID == s.orderID {
+		return nil, errors.New("expire failed")
+	}
+
+	return s.OrderService.Expire(ctx, orderID, sourceEventID)
+}
+
+func TestExpirationSweeper(t *testing.T) {
+	// orders of this test expire an hour before testClock, and the sweeper
+	// runs half an hour before it, so orders of other tests are never due
+	overdue := testClock.Now().Add(-time.Hour)
+	sweepClock := testutil.FixedClock(testClock.Now().Add(-30 * time.Minute))
+	placeOverdueOrder := func(t *testing.T, expiresAt time.Time) *entity.Order {
+		t.Helper()
+		ticket := testutil.InsertTicket(t, ticketRepo, "ticket", money.New(1200, "USD"))
+		order, err := orderService.Create(context.Background(), 6, &model.OrderDTO{TicketID: ticket.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := db.SQL.Exec(`UPDATE orders SET expires_at = $1 WHERE id = $2`, expiresAt, order.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		return order
+	}
+	assertStatus := func(t *testing.T, orderID int64, want string) {
+		t.Helper()
+		order, err := orderRepo.FindOne(context.Background(), orderID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if order.Status != want {
+			t.Errorf("expecting order %d to be %q but got %q instead", orderID, want, order.Status)
+		}
+	}
+
+	t.Run("sweeper cancels overdue orders", func(t *testing.T) {
+		order := placeOverdueOrder(t, overdue)
+
+		expirationSweeper := NewExpirationSweeper(orderRepo, transactor, orderService, sweepClock, time.Second, 0, 100)
+		if err := expirationSweeper.Sweep(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		assertStatus(t, order.ID, constant.CANCELLED)
+	})
+
+	t.Run("sweeper passes over an order that fails to expire", func(t *testing.T) {
+		failing := placeOverdueOrder(t, overdue.Add(-time.Minute))
+		order := placeOverdueOrder(t, overdue)
+
+		expiry := &failingExpiry{OrderService: orderService, orderID: failing.ID}
+		expirationSweeper := NewExpirationSweeper(orderRepo, transactor, expiry, sweepClock, time.Second, 0, 100)
+		if err := expirationSweeper.Sweep(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		assertStatus(t, failing.ID, constant.CREATED)
+		assertStatus(t, order.ID, constant.CANCELLED)
+	})
+}
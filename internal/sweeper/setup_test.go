@@ -0,0 +1,37 @@
+package sweeper
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/config"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+	"github.com/muktiarafi/ticketing-orders/internal/testutil"
+)
+
+var ticketRepo repository.TicketRepository
+var orderRepo repository.OrderRepository
+var transactor repository.Transactor
+var orderService service.OrderService
+var db *driver.DB
+
+var testClock = testutil.FixedClock(time.Now().UTC().Truncate(time.Second))
+
+func TestMain(m *testing.M) {
+	var purge func()
+	db, purge = testutil.NewDatabase()
+
+	ticketRepo = repository.NewTicketRepository(db)
+	orderRepo = repository.NewOrderRepository(db)
+	transactor = repository.NewTransactor(db)
+	orderService = testutil.NewOrderService(db, &config.Limits{}, &config.Expiration{Default: time.Minute}, testClock)
+
+	code := m.Run()
+
+	purge()
+
+	os.Exit(code)
+}
@@ -0,0 +1,95 @@
+package sweeper
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/clock"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+)
+
+// TicketResyncer gives up on ticket updates parked for longer than maxWait,
+// resyncing their tickets through TicketService.Resync. The parked rows are
+// locked while a ticket is resynced, so running it on every replica is safe.
+type TicketResyncer struct {
+	repository.ParkedTicketUpdateRepository
+	repository.Transactor
+	service.TicketService
+	clock     clock.Clock
+	interval  time.Duration
+	maxWait   time.Duration
+	batchSize int
+}
+
+func NewTicketResyncer(
+	parkedTicketUpdateRepo repository.ParkedTicketUpdateRepository,
+	transactor repository.Transactor,
+	ticketService service.TicketService,
+	clk clock.Clock,
+	interval time.Duration,
+	maxWait time.Duration,
+	batchSize int,
+) *TicketResyncer {
+	return &TicketResyncer{
+		ParkedTicketUpdateRepository: parkedTicketUpdateRepo,
+		Transactor:                   transactor,
+		TicketService:                ticketService,
+		clock:                        clk,
+		interval:                     interval,
+		maxWait:                      maxWait,
+		batchSize:                    batchSize,
+	}
+}
+
+func (r *TicketResyncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Sweep(ctx); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// Sweep resyncs up to batchSize tickets with stale parked updates.
+func (r *TicketResyncer) Sweep(ctx context.Context) error {
+	for i := 0; i < r.batchSize; i++ {
+		resynced, err := r.resyncNext(ctx)
+		if err != nil {
+			return err
+		}
+		if !resynced {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (r *TicketResyncer) resyncNext(ctx context.Context) (bool, error) {
+	resynced := false
+	err := r.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		deadline := r.clock.Now().UTC().Add(-r.maxWait)
+		ticketIDs, err := r.ParkedTicketUpdateRepository.FindStale(ctx, deadline, 1)
+		if err != nil || len(ticketIDs) == 0 {
+			return err
+		}
+
+		if err := r.TicketService.Resync(ctx, ticketIDs[0]); err != nil {
+			return err
+		}
+		resynced = true
+
+		return nil
+	})
+
+	return resynced, err
+}
@@ -0,0 +1,62 @@
+// Package testutil holds the fixtures shared by the integration tests of
+// several packages. Each test binary gets its own Postgres container.
+package testutil
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"path/filepath"
+	"runtime"
+
+	_ "github.com/jackc/pgconn"
+	_ "github.com/jackc/pgx/v4"
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/ory/dockertest/v3"
+)
+
+// MigrationFilePath points at db/migrations wherever the test runs from.
+var MigrationFilePath = migrationFilePath()
+
+func migrationFilePath() string {
+	_, file, _, _ := runtime.Caller(0)
+
+	return filepath.Join(filepath.Dir(file), "..", "..", "db", "migrations")
+}
+
+// NewDatabase starts a migrated Postgres container. The returned function
+// removes it.
+func NewDatabase() (*driver.DB, func()) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	resource, err := pool.Run("postgres", "alpine", []string{"POSTGRES_PASSWORD=secret", "POSTGRES_DB=postgres"})
+	if err != nil {
+		log.Fatalf("Could not start resource: %s", err)
+	}
+
+	var db *sql.DB
+	if err = pool.Retry(func() error {
+		db, err = sql.Open(
+			"pgx",
+			fmt.Sprintf("host=localhost port=%s dbname=postgres user=postgres password=secret", resource.GetPort("5432/tcp")))
+		if err != nil {
+			return err
+		}
+
+		return driver.Migration(MigrationFilePath, db)
+	}); err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	purge := func() {
+		if err := pool.Purge(resource); err != nil {
+			log.Fatalf("Could not purge resource: %s", err)
+		}
+	}
+
+	return &driver.DB{SQL: db}, purge
+}
@@ -0,0 +1,56 @@
+package testutil
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/entity"
+	"github.com/muktiarafi/ticketing-orders/internal/money"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+)
+
+// ticketIDs starts well above any id a test still writes by hand.
+var ticketIDs int64 = 1000000
+
+// NextTicketID returns a ticket id no other test uses, so tests don't depend
+// on the order they run in.
+func NextTicketID() int64 {
+	return atomic.AddInt64(&ticketIDs, 1)
+}
+
+// InsertTicket stores a ticket under a fresh id.
+func InsertTicket(t testing.TB, ticketRepo repository.TicketRepository, title string, price money.Money) *entity.Ticket {
+	t.Helper()
+
+	ticket, err := ticketRepo.Insert(context.Background(), &entity.Ticket{
+		ID:    NextTicketID(),
+		Title: title,
+		Price: price,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ticket
+}
+
+func CountOutboxMessages(t testing.TB, db *driver.DB, topic string) int {
+	t.Helper()
+
+	var count int
+	stmt := `SELECT COUNT(*) FROM outbox WHERE topic = $1`
+	if err := db.SQL.QueryRow(stmt, topic).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+
+	return count
+}
+
+type FixedClock time.Time
+
+func (c FixedClock) Now() time.Time {
+	return time.Time(c)
+}
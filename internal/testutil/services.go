@@ -0,0 +1,38 @@
+package testutil
+
+import (
+	"github.com/muktiarafi/ticketing-orders/internal/clock"
+	"github.com/muktiarafi/ticketing-orders/internal/config"
+	"github.com/muktiarafi/ticketing-orders/internal/driver"
+	"github.com/muktiarafi/ticketing-orders/internal/events/outbox"
+	"github.com/muktiarafi/ticketing-orders/internal/events/producer"
+	"github.com/muktiarafi/ticketing-orders/internal/repository"
+	"github.com/muktiarafi/ticketing-orders/internal/service"
+	"github.com/muktiarafi/ticketing-orders/internal/statemachine"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewOrderService wires an order service the way the server does, with its
+// events written to the outbox of db.
+func NewOrderService(db *driver.DB, limits *config.Limits, expiration *config.Expiration, clock clock.Clock) service.OrderService {
+	orderRepo := repository.NewOrderRepository(db)
+	outboxPublisher := outbox.NewPublisher(repository.NewOutboxRepository(db))
+
+	return service.NewOrderService(
+		orderRepo,
+		repository.NewTicketRepository(db),
+		repository.NewOrderEventRepository(db),
+		repository.NewWaitlistRepository(db),
+		repository.NewPaymentRepository(db),
+		producer.NewOrderProducer(outboxPublisher),
+		producer.NewWaitlistProducer(outboxPublisher),
+		repository.NewTransactor(db),
+		statemachine.NewOrderMachine(),
+		service.NewOrderLimiter(orderRepo, limits, prometheus.NewCounterVec(
+			prometheus.CounterOpts{Name: "order_limit_rejections_total"},
+			[]string{"rule"},
+		)),
+		expiration,
+		clock,
+	)
+}